@@ -1,14 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/goIdioms/conspect-generator/internal/config"
+	"github.com/goIdioms/conspect-generator/internal/constants"
 	"github.com/goIdioms/conspect-generator/internal/router"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
 	"github.com/joho/godotenv"
 	_ "github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
 )
 
 func init() {
@@ -16,25 +24,122 @@ func init() {
 }
 
 func run() {
+	cfg := config.NewServerConfig()
+
 	r := router.NewRouter()
 	r.SetupMiddlewares()
 	r.SetupRoutes()
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		r.Logger.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+
+	srv := &http.Server{
+		Handler:           r.Router,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
+	}
+
+	listener, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		r.Logger.Fatalf("Server failed to start: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
 
+	var metricsSrv *http.Server
+	if r.MetricsAddr != "" {
+		metricsSrv = &http.Server{
+			Addr:              r.MetricsAddr,
+			Handler:           r.MetricsHandler(),
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		}
+		go func() {
+			if err := metricsSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				r.Logger.Errorf("Metrics server failed: %v", err)
+			}
+		}()
+	}
+
+	if err := serve(ctx, srv, listener, r, r.Logger, cfg, cancelBase); err != nil {
+		r.Logger.Fatalf("Server failed: %v", err)
+	}
+	if metricsSrv != nil {
+		metricsSrv.Close()
+	}
+
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), constants.ShutdownAbortGrace)
+	defer cancelFlush()
+	if err := shutdownTracing(flushCtx); err != nil {
+		r.Logger.Errorf("Error flushing traces: %v", err)
+	}
+
+	if err := r.Close(); err != nil {
+		r.Logger.Errorf("Error closing database connection: %v", err)
+	}
+	r.Logger.Info("Server stopped")
+}
+
+type lifecycle interface {
+	SetReady(ready bool)
+	Drain(ctx context.Context) error
+}
+
+func serve(ctx context.Context, srv *http.Server, listener net.Listener, app lifecycle, logger *logrus.Logger, cfg *config.ServerConfig, abort context.CancelFunc) error {
+	serverErr := make(chan error, 1)
 	go func() {
-		<-c
-		r.Logger.Info("Shutting down server...")
-		if err := r.Close(); err != nil {
-			r.Logger.Errorf("Error closing database connection: %v", err)
+		logger.Infof("Starting server on %s", listener.Addr())
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
 		}
-		os.Exit(0)
 	}()
 
-	r.Logger.Info("Starting server on port", os.Getenv("HTTP_ADDR"))
-	if err := http.ListenAndServe(os.Getenv("HTTP_ADDR"), r.Router); err != nil {
-		r.Logger.Fatalf("Server failed to start: %v", err)
+	app.SetReady(true)
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	app.SetReady(false)
+	logger.Infof("Shutting down server, readiness disabled for %s before draining", cfg.ReadinessDelay)
+	time.Sleep(cfg.ReadinessDelay)
+
+	shutdown(srv, app, logger, cfg.DrainTimeout, abort)
+	return nil
+}
+
+func shutdown(srv *http.Server, app lifecycle, logger *logrus.Logger, drainTimeout time.Duration, abort context.CancelFunc) {
+	drainCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	logger.Infof("Waiting up to %s for in-flight conversions to finish", drainTimeout)
+	err := srv.Shutdown(drainCtx)
+	if err == nil {
+		return
+	}
+
+	logger.Warnf("Drain period elapsed, aborting in-flight conversions: %v", err)
+	abort()
+
+	graceCtx, cancelGrace := context.WithTimeout(context.Background(), constants.ShutdownAbortGrace)
+	defer cancelGrace()
+
+	if err := app.Drain(graceCtx); err != nil {
+		logger.Errorf("Conversions did not stop after abort: %v", err)
+	}
+	if err := srv.Close(); err != nil {
+		logger.Errorf("Error closing server: %v", err)
 	}
 }
 
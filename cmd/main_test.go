@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	helperEnv          = "SHUTDOWN_TEST_HELPER"
+	fakeConversionTime = 500 * time.Millisecond
+)
+
+type fakeApp struct {
+	mu       sync.Mutex
+	ready    []bool
+	inFlight sync.WaitGroup
+	onReady  func(bool)
+}
+
+func (a *fakeApp) SetReady(ready bool) {
+	a.mu.Lock()
+	a.ready = append(a.ready, ready)
+	a.mu.Unlock()
+	if a.onReady != nil {
+		a.onReady(ready)
+	}
+}
+
+func (a *fakeApp) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		a.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *fakeApp) readiness() []bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]bool(nil), a.ready...)
+}
+
+func newTestServer(app *fakeApp, baseCtx context.Context, handler http.HandlerFunc) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/convert", func(w http.ResponseWriter, r *http.Request) {
+		app.inFlight.Add(1)
+		defer app.inFlight.Done()
+		handler(w, r)
+	})
+	return &http.Server{
+		Handler: mux,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
+	}
+}
+
+func quietLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestShutdownOnSignalDrainsSlowConversion(t *testing.T) {
+	if os.Getenv(helperEnv) == "1" {
+		runShutdownHelper()
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestShutdownOnSignalDrainsSlowConversion$")
+	cmd.Env = append(os.Environ(), helperEnv+"=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("stdout pipe: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("start helper: %v", err)
+	}
+	defer cmd.Process.Kill()
+
+	lines := make(chan string, 8)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	addr := expectLine(t, lines, "listening ")
+
+	type response struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		resp, err := http.Get("http://" + addr + "/convert")
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- response{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	expectLine(t, lines, "conversion started")
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatalf("send SIGTERM: %v", err)
+	}
+	expectLine(t, lines, "ready false")
+
+	select {
+	case resp := <-responses:
+		if resp.err != nil {
+			t.Fatalf("in-flight request failed: %v", resp.err)
+		}
+		if resp.status != http.StatusOK || resp.body != "converted" {
+			t.Fatalf("in-flight request = %d %q, want 200 %q", resp.status, resp.body, "converted")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("in-flight request did not complete")
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	select {
+	case err := <-exited:
+		if err != nil {
+			t.Fatalf("process exited with error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("process did not exit after shutdown")
+	}
+}
+
+func runShutdownHelper() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+
+	app := &fakeApp{onReady: func(ready bool) { fmt.Printf("ready %t\n", ready) }}
+	srv := newTestServer(app, baseCtx, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Println("conversion started")
+		select {
+		case <-time.After(fakeConversionTime):
+			w.Write([]byte("converted"))
+		case <-r.Context().Done():
+			http.Error(w, "aborted", http.StatusServiceUnavailable)
+		}
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	fmt.Printf("listening %s\n", listener.Addr())
+
+	cfg := &config.ServerConfig{DrainTimeout: 10 * time.Second}
+	if err := serve(ctx, srv, listener, app, quietLogger(), cfg, cancelBase); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func expectLine(t *testing.T, lines <-chan string, prefix string) string {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("helper exited before printing %q", prefix)
+			}
+			if strings.HasPrefix(line, prefix) {
+				return strings.TrimPrefix(line, prefix)
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %q", prefix)
+		}
+	}
+}
+
+func TestShutdownAbortsConversionsAfterDrainTimeout(t *testing.T) {
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+
+	started := make(chan struct{})
+	aborted := make(chan struct{})
+	app := &fakeApp{}
+	srv := newTestServer(app, baseCtx, func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		close(aborted)
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cfg := &config.ServerConfig{DrainTimeout: 100 * time.Millisecond}
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, srv, listener, app, quietLogger(), cfg, cancelBase) }()
+
+	go http.Get("http://" + listener.Addr().String() + "/convert")
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("conversion did not start")
+	}
+	cancel()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Fatalf("serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after the drain timeout")
+	}
+
+	select {
+	case <-aborted:
+	default:
+		t.Fatal("in-flight conversion was not aborted")
+	}
+	if got := app.readiness(); len(got) != 2 || !got[0] || got[1] {
+		t.Fatalf("readiness transitions = %v, want [true false]", got)
+	}
+}
@@ -0,0 +1,150 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type Code string
+
+const (
+	CodeInvalidRequest       Code = "invalid_request"
+	CodeFileMissing          Code = "file_missing"
+	CodeFileTooLarge         Code = "file_too_large"
+	CodeFileEmpty            Code = "file_empty"
+	CodeFileUnreadable       Code = "file_unreadable"
+	CodeAudioTooLong         Code = "audio_too_long"
+	CodeUnsupportedMime      Code = "unsupported_mime"
+	CodeUnsupportedExtension Code = "unsupported_extension"
+	CodeVideoUnsupported     Code = "video_unsupported"
+	CodeUnsupportedDocument  Code = "unsupported_document"
+	CodeDocumentTooLong      Code = "document_too_long"
+	CodeChecksumMismatch     Code = "checksum_mismatch"
+	CodeUnsupportedVersion   Code = "unsupported_version"
+	CodeInvalidParam         Code = "invalid_param"
+	CodeUnsupportedLanguage  Code = "unsupported_language"
+	CodeQuotaExceeded        Code = "quota_exceeded"
+	CodeUnauthorized         Code = "unauthorized"
+	CodeSessionInvalid       Code = "session_invalid"
+	CodeNotFound             Code = "not_found"
+	CodeConflict             Code = "conflict"
+	CodeTranscriptionFailed  Code = "transcription_failed"
+	CodeSummarizationFailed  Code = "summarization_failed"
+	CodeRenderFailed         Code = "render_failed"
+	CodeServiceUnavailable   Code = "service_unavailable"
+	CodeInternal             Code = "internal_error"
+)
+
+const StatusChecksumMismatch = 460
+
+var titleByStatus = map[int]string{
+	StatusChecksumMismatch: "Checksum Mismatch",
+}
+
+var statusByCode = map[Code]int{
+	CodeInvalidRequest:       http.StatusBadRequest,
+	CodeFileMissing:          http.StatusBadRequest,
+	CodeFileTooLarge:         http.StatusRequestEntityTooLarge,
+	CodeFileEmpty:            http.StatusBadRequest,
+	CodeFileUnreadable:       http.StatusBadRequest,
+	CodeAudioTooLong:         http.StatusBadRequest,
+	CodeUnsupportedMime:      http.StatusUnsupportedMediaType,
+	CodeUnsupportedExtension: http.StatusUnsupportedMediaType,
+	CodeVideoUnsupported:     http.StatusUnsupportedMediaType,
+	CodeUnsupportedDocument:  http.StatusUnsupportedMediaType,
+	CodeDocumentTooLong:      http.StatusBadRequest,
+	CodeChecksumMismatch:     StatusChecksumMismatch,
+	CodeUnsupportedVersion:   http.StatusPreconditionFailed,
+	CodeInvalidParam:         http.StatusBadRequest,
+	CodeUnsupportedLanguage:  http.StatusBadRequest,
+	CodeQuotaExceeded:        http.StatusTooManyRequests,
+	CodeUnauthorized:         http.StatusUnauthorized,
+	CodeSessionInvalid:       http.StatusUnauthorized,
+	CodeNotFound:             http.StatusNotFound,
+	CodeConflict:             http.StatusConflict,
+	CodeTranscriptionFailed:  http.StatusBadGateway,
+	CodeSummarizationFailed:  http.StatusBadGateway,
+	CodeRenderFailed:         http.StatusInternalServerError,
+	CodeServiceUnavailable:   http.StatusServiceUnavailable,
+	CodeInternal:             http.StatusInternalServerError,
+}
+
+type Error struct {
+	Code    Code
+	Message string
+	Field   string
+	Details map[string]any
+	Err     error
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func Wrap(code Code, message string, err error) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Status() int {
+	if status, ok := statusByCode[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+func (e *Error) WithField(field string) *Error {
+	e.Field = field
+	return e
+}
+
+func (e *Error) WithDetail(key string, value any) *Error {
+	if e.Details == nil {
+		e.Details = make(map[string]any)
+	}
+	e.Details[key] = value
+	return e
+}
+
+func Codes() []Code {
+	codes := make([]Code, 0, len(statusByCode))
+	for code := range statusByCode {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+type coder interface {
+	AppError() *Error
+}
+
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var c coder
+	if errors.As(err, &c) {
+		return c.AppError()
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return Wrap(CodeFileTooLarge, "Request body is too large", err).
+			WithDetail("max_mb", maxBytesErr.Limit/(1024*1024))
+	}
+
+	return Wrap(CodeInternal, "Internal server error", err)
+}
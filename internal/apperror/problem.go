@@ -0,0 +1,76 @@
+package apperror
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/i18n"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+const problemTypePrefix = "/problems/"
+
+type Problem struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Status    int            `json:"status"`
+	Detail    string         `json:"detail,omitempty"`
+	Instance  string         `json:"instance,omitempty"`
+	Code      Code           `json:"code"`
+	Field     string         `json:"field,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+}
+
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	appErr := From(err)
+	status := appErr.Status()
+
+	log := logging.FromContext(r.Context()).WithField("error_code", appErr.Code)
+	if status >= http.StatusInternalServerError {
+		log.Errorf("Request failed: %v", err)
+	} else {
+		log.Warnf("Request rejected: %v", err)
+	}
+
+	problem := Problem{
+		Type:      problemTypePrefix + string(appErr.Code),
+		Title:     title(status),
+		Status:    status,
+		Detail:    localize(i18n.FromContext(r.Context()), appErr),
+		Instance:  r.URL.Path,
+		Code:      appErr.Code,
+		Field:     appErr.Field,
+		Details:   appErr.Details,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+
+	w.Header().Set(c.HeaderContentType, c.ContentTypeProblemJSON)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+func title(status int) string {
+	if text, ok := titleByStatus[status]; ok {
+		return text
+	}
+	return http.StatusText(status)
+}
+
+func localize(lang i18n.Lang, appErr *Error) string {
+	key := string(appErr.Code)
+	if appErr.Field != "" && i18n.Has(i18n.DefaultLang, key+"."+appErr.Field) {
+		key += "." + appErr.Field
+	}
+	if !i18n.Has(i18n.DefaultLang, key) {
+		return appErr.Message
+	}
+
+	params := map[string]any{"field": appErr.Field}
+	for name, value := range appErr.Details {
+		params[name] = value
+	}
+	return i18n.T(lang, key, params)
+}
@@ -0,0 +1,28 @@
+package apperror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWriteSetsTitleForEveryCode(t *testing.T) {
+	for _, code := range Codes() {
+		t.Run(string(code), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			Write(rec, httptest.NewRequest(http.MethodGet, "/api/v1/test", nil), New(code, "test"))
+
+			var problem Problem
+			if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if problem.Status != rec.Code {
+				t.Fatalf("problem status = %d, response status = %d", problem.Status, rec.Code)
+			}
+			if problem.Title == "" {
+				t.Fatalf("problem for status %d has an empty title", problem.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	domainArtifact "github.com/goIdioms/conspect-generator/internal/domain/artifact"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+type Service struct {
+	artifactRepo domainArtifact.Repository
+	storage      domainArtifact.Storage
+	retention    map[domainArtifact.Kind]time.Duration
+	urlTTL       time.Duration
+}
+
+func NewService(artifactRepo domainArtifact.Repository, storage domainArtifact.Storage, retention map[domainArtifact.Kind]time.Duration, urlTTL time.Duration) *Service {
+	return &Service{
+		artifactRepo: artifactRepo,
+		storage:      storage,
+		retention:    retention,
+		urlTTL:       urlTTL,
+	}
+}
+
+func (s *Service) Save(ctx context.Context, conspectID int, kind domainArtifact.Kind, filename, contentType string, r io.Reader, size int64) (*domainArtifact.Artifact, error) {
+	artifact := domainArtifact.NewArtifact(conspectID, kind, filename, contentType, size, s.retention[kind])
+
+	if err := s.storage.Put(ctx, artifact.Key, r, size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store %s artifact: %w", kind, err)
+	}
+
+	if err := s.artifactRepo.Create(ctx, artifact); err != nil {
+		if deleteErr := s.storage.Delete(ctx, artifact.Key); deleteErr != nil {
+			logging.FromContext(ctx).Warnf("Failed to delete orphaned object %s: %v", artifact.Key, deleteErr)
+		}
+		return nil, fmt.Errorf("failed to save %s artifact: %w", kind, err)
+	}
+
+	logging.FromContext(ctx).WithField("conspect_id", conspectID).Infof("Stored %s artifact %s", kind, artifact.Key)
+	return artifact, nil
+}
+
+func (s *Service) List(ctx context.Context, conspectID int) ([]*domainArtifact.Artifact, error) {
+	artifacts, err := s.artifactRepo.FindByConspect(ctx, conspectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	now := time.Now()
+	available := make([]*domainArtifact.Artifact, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		if !artifact.IsExpired(now) {
+			available = append(available, artifact)
+		}
+	}
+	return available, nil
+}
+
+func (s *Service) Latest(ctx context.Context, conspectID int, kind domainArtifact.Kind) (*domainArtifact.Artifact, error) {
+	artifacts, err := s.List(ctx, conspectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, artifact := range artifacts {
+		if artifact.Kind == kind {
+			return artifact, nil
+		}
+	}
+	return nil, domainArtifact.ErrArtifactNotFound
+}
+
+func (s *Service) Link(ctx context.Context, artifact *domainArtifact.Artifact) (string, time.Time, error) {
+	ttl := s.urlTTL
+	if artifact.ExpiresAt != nil {
+		ttl = min(ttl, time.Until(*artifact.ExpiresAt))
+	}
+
+	url, err := s.storage.SignedURL(ctx, artifact.Key, artifact.Filename, ttl)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign artifact URL: %w", err)
+	}
+	return url, time.Now().Add(ttl), nil
+}
+
+func (s *Service) Cleanup(ctx context.Context) (int, error) {
+	expired, err := s.artifactRepo.FindExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired artifacts: %w", err)
+	}
+
+	removed := 0
+	for _, artifact := range expired {
+		if err := s.storage.Delete(ctx, artifact.Key); err != nil {
+			return removed, fmt.Errorf("failed to delete artifact object: %w", err)
+		}
+		if err := s.artifactRepo.Delete(ctx, artifact.ID); err != nil && !errors.Is(err, domainArtifact.ErrArtifactNotFound) {
+			return removed, fmt.Errorf("failed to delete artifact: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (s *Service) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := s.Cleanup(ctx)
+			if err != nil {
+				logging.FromContext(ctx).Errorf("Failed to clean up expired artifacts: %v", err)
+			}
+			if removed > 0 {
+				logging.FromContext(ctx).Infof("Removed %d expired artifacts", removed)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+type Service struct {
+	cache     domainConspect.Cache
+	retention time.Duration
+}
+
+func NewService(cache domainConspect.Cache, retention time.Duration) *Service {
+	return &Service{
+		cache:     cache,
+		retention: retention,
+	}
+}
+
+func (s *Service) Cleanup(ctx context.Context) (int64, error) {
+	removed, err := s.cache.DeleteUnusedBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return removed, fmt.Errorf("failed to clean up cache: %w", err)
+	}
+	return removed, nil
+}
+
+func (s *Service) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := s.Cleanup(ctx)
+			if err != nil {
+				logging.FromContext(ctx).Errorf("Failed to clean up unused cache entries: %v", err)
+			}
+			if removed > 0 {
+				logging.FromContext(ctx).Infof("Removed %d unused cache entries", removed)
+			}
+		}
+	}
+}
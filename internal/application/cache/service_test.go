@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+type memoryCache struct {
+	mu     sync.Mutex
+	usedAt map[string]time.Time
+}
+
+func (c *memoryCache) FindTranscript(context.Context, string) (*domainConspect.CachedTranscript, error) {
+	return nil, domainConspect.ErrCacheMiss
+}
+
+func (c *memoryCache) SaveTranscript(context.Context, string, *domainConspect.CachedTranscript) error {
+	return nil
+}
+
+func (c *memoryCache) FindSummary(context.Context, string) (*domainConspect.CachedSummary, error) {
+	return nil, domainConspect.ErrCacheMiss
+}
+
+func (c *memoryCache) SaveSummary(context.Context, string, *domainConspect.CachedSummary) error {
+	return nil
+}
+
+func (c *memoryCache) DeleteUnusedBefore(_ context.Context, before time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var removed int64
+	for key, usedAt := range c.usedAt {
+		if usedAt.Before(before) {
+			delete(c.usedAt, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func TestCleanupRemovesEntriesUnusedForRetention(t *testing.T) {
+	now := time.Now()
+	cache := &memoryCache{usedAt: map[string]time.Time{
+		"expired transcript": now.Add(-31 * 24 * time.Hour),
+		"expired summary":    now.Add(-30*24*time.Hour - time.Minute),
+		"recent transcript":  now.Add(-29 * 24 * time.Hour),
+		"just used summary":  now,
+	}}
+	s := NewService(cache, 30*24*time.Hour)
+
+	removed, err := s.Cleanup(context.Background())
+	if err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if removed != 2 {
+		t.Fatalf("Cleanup() removed %d entries, want 2", removed)
+	}
+	for _, key := range []string{"recent transcript", "just used summary"} {
+		if _, ok := cache.usedAt[key]; !ok {
+			t.Fatalf("Cleanup() removed %q", key)
+		}
+	}
+}
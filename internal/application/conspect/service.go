@@ -0,0 +1,229 @@
+package conspect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+type Service struct {
+	conspectRepo domainConspect.Repository
+	revisionRepo domainConspect.RevisionRepository
+}
+
+func NewService(conspectRepo domainConspect.Repository, revisionRepo domainConspect.RevisionRepository) *Service {
+	return &Service{
+		conspectRepo: conspectRepo,
+		revisionRepo: revisionRepo,
+	}
+}
+
+func (s *Service) Start(ctx context.Context, userID *int, jobID, filename string, audio domainConspect.AudioInfo, opts domainConspect.Options) (*domainConspect.Conspect, error) {
+	conspect := domainConspect.NewConspect(userID, jobID, filename, audio, opts)
+
+	if err := s.conspectRepo.Create(ctx, conspect); err != nil {
+		return nil, fmt.Errorf("failed to create conspect: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("conspect_id", conspect.ID).Info("Started conspect")
+	return conspect, nil
+}
+
+func (s *Service) StartVersion(ctx context.Context, parent *domainConspect.Conspect, jobID string, opts domainConspect.Options, layout string) (*domainConspect.Conspect, error) {
+	conspect := parent.NewVersion(jobID, opts, layout)
+
+	if err := s.conspectRepo.Create(ctx, conspect); err != nil {
+		return nil, fmt.Errorf("failed to create conspect version: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("conspect_id", conspect.ID).WithField("parent_id", parent.ID).Info("Started conspect version")
+	return conspect, nil
+}
+
+func (s *Service) Complete(ctx context.Context, conspect *domainConspect.Conspect, result domainConspect.Result) error {
+	conspect.Complete(result)
+
+	if err := s.conspectRepo.Update(ctx, conspect); err != nil {
+		return fmt.Errorf("failed to complete conspect: %w", err)
+	}
+
+	if !result.Transcript.IsZero() {
+		if err := s.conspectRepo.SaveTranscript(ctx, conspect.ID, result.Transcript); err != nil {
+			return fmt.Errorf("failed to save transcript: %w", err)
+		}
+	}
+
+	if err := s.revisionRepo.Create(ctx, conspect, domainConspect.NewInitialRevision(conspect)); err != nil {
+		return fmt.Errorf("failed to save initial revision: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("conspect_id", conspect.ID).Info("Completed conspect")
+	return nil
+}
+
+func (s *Service) Fail(ctx context.Context, conspect *domainConspect.Conspect, errorCode string) error {
+	conspect.Fail(errorCode)
+
+	if err := s.conspectRepo.Update(ctx, conspect); err != nil {
+		return fmt.Errorf("failed to mark conspect as failed: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("conspect_id", conspect.ID).Warn("Conspect failed")
+	return nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id int) (*domainConspect.Conspect, error) {
+	conspect, err := s.conspectRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domainConspect.ErrConspectNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get conspect by ID: %w", err)
+	}
+	return conspect, nil
+}
+
+func (s *Service) GetForViewer(ctx context.Context, id int, userID *int, jobID string) (*domainConspect.Conspect, error) {
+	conspect, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !conspect.CanBeViewedBy(userID, jobID) {
+		return nil, domainConspect.ErrConspectNotFound
+	}
+	return conspect, nil
+}
+
+func (s *Service) GetTranscript(ctx context.Context, conspect *domainConspect.Conspect) (*domainConspect.Transcript, error) {
+	transcript, err := s.conspectRepo.FindTranscript(ctx, conspect.ID)
+	if err != nil {
+		if errors.Is(err, domainConspect.ErrTranscriptNotFound) || errors.Is(err, domainConspect.ErrConspectNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get transcript: %w", err)
+	}
+	return transcript, nil
+}
+
+func (s *Service) RenameSpeakers(ctx context.Context, conspect *domainConspect.Conspect, names map[string]string) error {
+	if err := conspect.Speakers.Rename(names); err != nil {
+		return err
+	}
+
+	if err := s.conspectRepo.Update(ctx, conspect); err != nil {
+		return fmt.Errorf("failed to rename speakers: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("conspect_id", conspect.ID).Info("Renamed speakers")
+	return nil
+}
+
+func (s *Service) Revisions(ctx context.Context, conspect *domainConspect.Conspect) ([]*domainConspect.Revision, error) {
+	revisions, err := s.revisionRepo.FindByConspect(ctx, conspect.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (s *Service) GetRevision(ctx context.Context, conspect *domainConspect.Conspect, number int) (*domainConspect.Revision, error) {
+	revision, err := s.revisionRepo.FindByNumber(ctx, conspect.ID, number)
+	if err != nil {
+		if errors.Is(err, domainConspect.ErrRevisionNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+	return revision, nil
+}
+
+func (s *Service) AtRevision(ctx context.Context, conspect *domainConspect.Conspect, number int) (*domainConspect.Conspect, error) {
+	if number == conspect.Revision {
+		return conspect, nil
+	}
+
+	revision, err := s.GetRevision(ctx, conspect, number)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := *conspect
+	snapshot.ApplyRevision(revision)
+	return &snapshot, nil
+}
+
+func (s *Service) Edit(ctx context.Context, conspect *domainConspect.Conspect, content string, authorID *int, baseRevision *int) (*domainConspect.Revision, error) {
+	head, err := s.head(ctx, conspect, baseRevision)
+	if err != nil {
+		return nil, err
+	}
+	if head.SameContent(content) {
+		return head, nil
+	}
+
+	revision, err := head.Edit(content, authorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.revisionRepo.Create(ctx, conspect, revision); err != nil {
+		if errors.Is(err, domainConspect.ErrRevisionConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save revision: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("conspect_id", conspect.ID).Infof("Saved revision %d", revision.Number)
+	return revision, nil
+}
+
+func (s *Service) Rollback(ctx context.Context, conspect *domainConspect.Conspect, number int, authorID *int, baseRevision *int) (*domainConspect.Revision, error) {
+	head, err := s.head(ctx, conspect, baseRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.GetRevision(ctx, conspect, number)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := head.Restore(target, authorID)
+	if err := s.revisionRepo.Create(ctx, conspect, revision); err != nil {
+		if errors.Is(err, domainConspect.ErrRevisionConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to roll back revision: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("conspect_id", conspect.ID).Infof("Rolled back to revision %d as revision %d", number, revision.Number)
+	return revision, nil
+}
+
+func (s *Service) Diff(ctx context.Context, conspect *domainConspect.Conspect, from, to int) ([]domainConspect.DiffLine, error) {
+	fromRevision, err := s.GetRevision(ctx, conspect, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.GetRevision(ctx, conspect, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return domainConspect.Diff(
+		conspect.Speakers.Resolve(fromRevision.Content),
+		conspect.Speakers.Resolve(toRevision.Content),
+	), nil
+}
+
+func (s *Service) head(ctx context.Context, conspect *domainConspect.Conspect, baseRevision *int) (*domainConspect.Revision, error) {
+	if !conspect.IsCompleted() || conspect.Revision == 0 {
+		return nil, domainConspect.ErrNotCompleted
+	}
+	if baseRevision != nil && *baseRevision != conspect.Revision {
+		return nil, domainConspect.ErrRevisionConflict
+	}
+	return s.GetRevision(ctx, conspect, conspect.Revision)
+}
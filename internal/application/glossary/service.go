@@ -0,0 +1,95 @@
+package glossary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainGlossary "github.com/goIdioms/conspect-generator/internal/domain/glossary"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+type Service struct {
+	glossaryRepo domainGlossary.Repository
+}
+
+func NewService(glossaryRepo domainGlossary.Repository) *Service {
+	return &Service{
+		glossaryRepo: glossaryRepo,
+	}
+}
+
+func (s *Service) List(ctx context.Context, userID int) ([]*domainGlossary.Glossary, error) {
+	glossaries, err := s.glossaryRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossaries: %w", err)
+	}
+	return glossaries, nil
+}
+
+func (s *Service) Get(ctx context.Context, id, userID int) (*domainGlossary.Glossary, error) {
+	glossary, err := s.glossaryRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domainGlossary.ErrGlossaryNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get glossary: %w", err)
+	}
+	if !glossary.IsOwnedBy(userID) {
+		return nil, domainGlossary.ErrGlossaryNotFound
+	}
+	return glossary, nil
+}
+
+func (s *Service) Create(ctx context.Context, userID int, name string, terms []domainGlossary.Term) (*domainGlossary.Glossary, error) {
+	glossary, err := domainGlossary.NewGlossary(userID, name, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.glossaryRepo.Create(ctx, glossary); err != nil {
+		if errors.Is(err, domainGlossary.ErrGlossaryExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create glossary: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("glossary_id", glossary.ID).Info("Created glossary")
+	return glossary, nil
+}
+
+func (s *Service) Update(ctx context.Context, id, userID int, name string, terms []domainGlossary.Term) (*domainGlossary.Glossary, error) {
+	glossary, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := glossary.Update(name, terms); err != nil {
+		return nil, err
+	}
+
+	if err := s.glossaryRepo.Update(ctx, glossary); err != nil {
+		if errors.Is(err, domainGlossary.ErrGlossaryNotFound) || errors.Is(err, domainGlossary.ErrGlossaryExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update glossary: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("glossary_id", glossary.ID).Info("Updated glossary")
+	return glossary, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id, userID int) error {
+	if _, err := s.Get(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.glossaryRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domainGlossary.ErrGlossaryNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete glossary: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("glossary_id", id).Info("Deleted glossary")
+	return nil
+}
@@ -0,0 +1,14 @@
+package session
+
+import "context"
+
+type userIDKey struct{}
+
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(int)
+	return userID, ok
+}
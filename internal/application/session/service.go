@@ -7,18 +7,16 @@ import (
 	"time"
 
 	domainSession "github.com/goIdioms/conspect-generator/internal/domain/session"
-	"github.com/sirupsen/logrus"
+	"github.com/goIdioms/conspect-generator/internal/logging"
 )
 
 type Service struct {
 	sessionRepo domainSession.Repository
-	logger      *logrus.Logger
 }
 
-func NewService(sessionRepo domainSession.Repository, logger *logrus.Logger) *Service {
+func NewService(sessionRepo domainSession.Repository) *Service {
 	return &Service{
 		sessionRepo: sessionRepo,
-		logger:      logger,
 	}
 }
 
@@ -30,7 +28,7 @@ func (s *Service) CreateSession(ctx context.Context, userID int, duration time.D
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	s.logger.Infof("Created session for user %d", userID)
+	logging.FromContext(ctx).WithField(logging.FieldUserID, userID).Info("Created session")
 	return session, nil
 }
 
@@ -65,7 +63,7 @@ func (s *Service) DeleteSession(ctx context.Context, tokenStr string) error {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
 
-	s.logger.Info("Deleted session")
+	logging.FromContext(ctx).Info("Deleted session")
 	return nil
 }
 
@@ -74,7 +72,7 @@ func (s *Service) DeleteUserSessions(ctx context.Context, userID int) error {
 		return fmt.Errorf("failed to delete user sessions: %w", err)
 	}
 
-	s.logger.Infof("Deleted all sessions for user %d", userID)
+	logging.FromContext(ctx).WithField(logging.FieldUserID, userID).Info("Deleted all sessions for user")
 	return nil
 }
 
@@ -85,7 +83,7 @@ func (s *Service) CleanExpiredSessions(ctx context.Context) error {
 	}
 
 	if count > 0 {
-		s.logger.Infof("Cleaned %d expired sessions", count)
+		logging.FromContext(ctx).Infof("Cleaned %d expired sessions", count)
 	}
 	return nil
 }
@@ -0,0 +1,113 @@
+package style
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domainStyle "github.com/goIdioms/conspect-generator/internal/domain/style"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+type Service struct {
+	styleRepo domainStyle.Repository
+	builtin   []*domainStyle.Style
+}
+
+func NewService(styleRepo domainStyle.Repository, builtin []*domainStyle.Style) *Service {
+	return &Service{
+		styleRepo: styleRepo,
+		builtin:   builtin,
+	}
+}
+
+func (s *Service) List(ctx context.Context) ([]*domainStyle.Style, error) {
+	custom, err := s.styleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list styles: %w", err)
+	}
+
+	styles := make([]*domainStyle.Style, 0, len(s.builtin)+len(custom))
+	styles = append(styles, s.builtin...)
+	for _, style := range custom {
+		if s.findBuiltin(style.Name) == nil {
+			styles = append(styles, style)
+		}
+	}
+	return styles, nil
+}
+
+func (s *Service) Get(ctx context.Context, name string) (*domainStyle.Style, error) {
+	if name == "" {
+		name = domainStyle.DefaultName
+	}
+	if style := s.findBuiltin(name); style != nil {
+		return style, nil
+	}
+
+	style, err := s.styleRepo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, domainStyle.ErrStyleNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get style: %w", err)
+	}
+	return style, nil
+}
+
+func (s *Service) Create(ctx context.Context, style *domainStyle.Style) error {
+	if s.findBuiltin(style.Name) != nil {
+		return domainStyle.ErrStyleExists
+	}
+
+	if err := s.styleRepo.Create(ctx, style); err != nil {
+		if errors.Is(err, domainStyle.ErrStyleExists) {
+			return err
+		}
+		return fmt.Errorf("failed to create style: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("style", style.Name).Info("Created style")
+	return nil
+}
+
+func (s *Service) Update(ctx context.Context, style *domainStyle.Style) error {
+	if s.findBuiltin(style.Name) != nil {
+		return domainStyle.ErrStyleReadOnly
+	}
+
+	if err := s.styleRepo.Update(ctx, style); err != nil {
+		if errors.Is(err, domainStyle.ErrStyleNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update style: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("style", style.Name).Info("Updated style")
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, name string) error {
+	if s.findBuiltin(name) != nil {
+		return domainStyle.ErrStyleReadOnly
+	}
+
+	if err := s.styleRepo.Delete(ctx, name); err != nil {
+		if errors.Is(err, domainStyle.ErrStyleNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete style: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("style", name).Info("Deleted style")
+	return nil
+}
+
+func (s *Service) findBuiltin(name string) *domainStyle.Style {
+	for _, style := range s.builtin {
+		if style.Name == name {
+			return style
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+	"time"
+
+	domainUpload "github.com/goIdioms/conspect-generator/internal/domain/upload"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+type Service struct {
+	uploadRepo domainUpload.Repository
+	store      domainUpload.Store
+	maxSize    int64
+	ttl        time.Duration
+	locks      sync.Map
+}
+
+func NewService(uploadRepo domainUpload.Repository, store domainUpload.Store, maxSize int64, ttl time.Duration) *Service {
+	return &Service{
+		uploadRepo: uploadRepo,
+		store:      store,
+		maxSize:    maxSize,
+		ttl:        ttl,
+	}
+}
+
+func (s *Service) MaxSize() int64 {
+	return s.maxSize
+}
+
+func (s *Service) Create(ctx context.Context, userID *int, length int64, metadata map[string]string) (*domainUpload.Upload, error) {
+	upload, err := domainUpload.NewUpload(userID, length, s.maxSize, metadata, s.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Create(ctx, upload.ID); err != nil {
+		return nil, fmt.Errorf("failed to create upload: %w", err)
+	}
+	if err := s.uploadRepo.Create(ctx, upload); err != nil {
+		s.store.Delete(context.WithoutCancel(ctx), upload.ID)
+		return nil, fmt.Errorf("failed to create upload: %w", err)
+	}
+
+	logging.FromContext(ctx).WithField("upload_id", upload.ID).Infof("Created upload: length=%d", upload.Length)
+	return upload, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string, userID *int) (*domainUpload.Upload, error) {
+	if err := domainUpload.ValidateID(id); err != nil {
+		return nil, domainUpload.ErrUploadNotFound
+	}
+
+	upload, err := s.uploadRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domainUpload.ErrUploadNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get upload: %w", err)
+	}
+	if !upload.CanBeAccessedBy(userID) || time.Now().After(upload.ExpiresAt) {
+		return nil, domainUpload.ErrUploadNotFound
+	}
+	return upload, nil
+}
+
+func (s *Service) Write(ctx context.Context, id string, userID *int, offset int64, checksum *domainUpload.Checksum, body io.Reader) (*domainUpload.Upload, error) {
+	upload, unlock, err := s.acquire(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	if offset != upload.Offset {
+		return upload, domainUpload.ErrOffsetMismatch
+	}
+
+	var h hash.Hash
+	if checksum != nil {
+		h = checksum.Hash()
+		body = io.TeeReader(body, h)
+	}
+
+	written, writeErr := s.store.Append(ctx, id, offset, io.LimitReader(body, upload.Remaining()+1))
+	switch {
+	case written > upload.Remaining():
+		writeErr = domainUpload.ErrExceedsLength
+	case writeErr == nil && h != nil && !checksum.Matches(h):
+		writeErr = domainUpload.ErrChecksumMismatch
+	}
+	if writeErr != nil && (h != nil || written > upload.Remaining()) {
+		written = 0
+	}
+
+	if written == 0 {
+		if err := s.store.Truncate(context.WithoutCancel(ctx), id, offset); err != nil {
+			logging.FromContext(ctx).WithField("upload_id", id).Errorf("Failed to discard rejected chunk: %v", err)
+		}
+		if writeErr != nil {
+			return upload, writeErr
+		}
+		return upload, nil
+	}
+
+	upload.Offset = offset + written
+	if err := s.uploadRepo.UpdateOffset(context.WithoutCancel(ctx), upload, offset); err != nil {
+		return upload, fmt.Errorf("failed to record upload offset: %w", err)
+	}
+
+	if upload.IsComplete() {
+		logging.FromContext(ctx).WithField("upload_id", id).Info("Upload completed")
+	}
+	return upload, writeErr
+}
+
+func (s *Service) Open(ctx context.Context, id string, userID *int) (*domainUpload.Upload, domainUpload.File, error) {
+	upload, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !upload.IsComplete() {
+		return nil, nil, domainUpload.ErrUploadIncomplete
+	}
+
+	file, err := s.store.Open(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open upload: %w", err)
+	}
+	return upload, file, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string, userID *int) error {
+	_, unlock, err := s.acquire(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.remove(ctx, id); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).WithField("upload_id", id).Info("Terminated upload")
+	return nil
+}
+
+func (s *Service) Cleanup(ctx context.Context) (int, error) {
+	expired, err := s.uploadRepo.FindExpired(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired uploads: %w", err)
+	}
+
+	removed := 0
+	for _, upload := range expired {
+		unlock, ok := s.lock(upload.ID)
+		if !ok {
+			continue
+		}
+		err := s.remove(ctx, upload.ID)
+		unlock()
+		if err != nil && !errors.Is(err, domainUpload.ErrUploadNotFound) {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (s *Service) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := s.Cleanup(ctx)
+			if err != nil {
+				logging.FromContext(ctx).Errorf("Failed to clean up expired uploads: %v", err)
+			}
+			if removed > 0 {
+				logging.FromContext(ctx).Infof("Removed %d expired uploads", removed)
+			}
+		}
+	}
+}
+
+func (s *Service) remove(ctx context.Context, id string) error {
+	if err := s.uploadRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domainUpload.ErrUploadNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+	if err := s.store.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+	s.locks.Delete(id)
+	return nil
+}
+
+func (s *Service) acquire(ctx context.Context, id string, userID *int) (*domainUpload.Upload, func(), error) {
+	if _, err := s.Get(ctx, id, userID); err != nil {
+		return nil, nil, err
+	}
+
+	unlock, ok := s.lock(id)
+	if !ok {
+		return nil, nil, domainUpload.ErrUploadLocked
+	}
+
+	upload, err := s.Get(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, domainUpload.ErrUploadNotFound) {
+			s.locks.Delete(id)
+		}
+		unlock()
+		return nil, nil, err
+	}
+	return upload, unlock, nil
+}
+
+func (s *Service) lock(id string) (func(), bool) {
+	value, _ := s.locks.LoadOrStore(id, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	if !mu.TryLock() {
+		return nil, false
+	}
+	return mu.Unlock, true
+}
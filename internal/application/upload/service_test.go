@@ -0,0 +1,155 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	domainUpload "github.com/goIdioms/conspect-generator/internal/domain/upload"
+	"github.com/goIdioms/conspect-generator/internal/infra/uploads"
+)
+
+type memoryRepository struct {
+	mu      sync.Mutex
+	uploads map[string]*domainUpload.Upload
+}
+
+func (r *memoryRepository) FindByID(_ context.Context, id string) (*domainUpload.Upload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upload, ok := r.uploads[id]
+	if !ok {
+		return nil, domainUpload.ErrUploadNotFound
+	}
+	copied := *upload
+	return &copied, nil
+}
+
+func (r *memoryRepository) FindExpired(_ context.Context, before time.Time) ([]*domainUpload.Upload, error) {
+	return nil, nil
+}
+
+func (r *memoryRepository) Create(_ context.Context, upload *domainUpload.Upload) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *upload
+	r.uploads[upload.ID] = &copied
+	return nil
+}
+
+func (r *memoryRepository) UpdateOffset(_ context.Context, upload *domainUpload.Upload, from int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.uploads[upload.ID]
+	if !ok || stored.Offset != from {
+		return domainUpload.ErrOffsetMismatch
+	}
+	stored.Offset = upload.Offset
+	return nil
+}
+
+func (r *memoryRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.uploads[id]; !ok {
+		return domainUpload.ErrUploadNotFound
+	}
+	delete(r.uploads, id)
+	return nil
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	store, err := uploads.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("create disk store: %v", err)
+	}
+	repo := &memoryRepository{uploads: map[string]*domainUpload.Upload{}}
+	return NewService(repo, store, 1024, time.Hour)
+}
+
+func lockCount(s *Service) int {
+	count := 0
+	s.locks.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func TestUnknownUploadsDoNotLeaveLocks(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"not-an-id", "0123456789abcdef0123456789abcdef"} {
+		if _, err := s.Write(ctx, id, nil, 0, nil, bytes.NewReader([]byte("data"))); !errors.Is(err, domainUpload.ErrUploadNotFound) {
+			t.Fatalf("Write(%q) error = %v, want %v", id, err, domainUpload.ErrUploadNotFound)
+		}
+		if err := s.Delete(ctx, id, nil); !errors.Is(err, domainUpload.ErrUploadNotFound) {
+			t.Fatalf("Delete(%q) error = %v, want %v", id, err, domainUpload.ErrUploadNotFound)
+		}
+	}
+
+	if got := lockCount(s); got != 0 {
+		t.Fatalf("locks after misses = %d, want 0", got)
+	}
+}
+
+func TestWriteAndDeleteReleaseLocks(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	upload, err := s.Create(ctx, nil, 8, map[string]string{"filename": "lecture.mp3"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	written, err := s.Write(ctx, upload.ID, nil, 0, nil, bytes.NewReader([]byte("lecture!")))
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if !written.IsComplete() {
+		t.Fatalf("upload offset = %d, want %d", written.Offset, written.Length)
+	}
+
+	_, file, err := s.Open(ctx, upload.ID, nil)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	content, err := io.ReadAll(file)
+	file.Close()
+	if err != nil || string(content) != "lecture!" {
+		t.Fatalf("stored content = %q, %v", content, err)
+	}
+
+	if err := s.Delete(ctx, upload.ID, nil); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got := lockCount(s); got != 0 {
+		t.Fatalf("locks after delete = %d, want 0", got)
+	}
+}
+
+func TestWriteRejectsConcurrentChunk(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	upload, err := s.Create(ctx, nil, 8, map[string]string{"filename": "lecture.mp3"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	unlock, ok := s.lock(upload.ID)
+	if !ok {
+		t.Fatal("lock() failed on an idle upload")
+	}
+	defer unlock()
+
+	if _, err := s.Write(ctx, upload.ID, nil, 0, nil, bytes.NewReader([]byte("data"))); !errors.Is(err, domainUpload.ErrUploadLocked) {
+		t.Fatalf("Write() error = %v, want %v", err, domainUpload.ErrUploadLocked)
+	}
+}
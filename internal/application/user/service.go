@@ -6,19 +6,18 @@ import (
 	"fmt"
 
 	domainUser "github.com/goIdioms/conspect-generator/internal/domain/user"
+	"github.com/goIdioms/conspect-generator/internal/logging"
 	"github.com/goIdioms/conspect-generator/internal/services"
 	"github.com/sirupsen/logrus"
 )
 
 type Service struct {
 	userRepo domainUser.Repository
-	logger   *logrus.Logger
 }
 
-func NewService(userRepo domainUser.Repository, logger *logrus.Logger) *Service {
+func NewService(userRepo domainUser.Repository) *Service {
 	return &Service{
 		userRepo: userRepo,
-		logger:   logger,
 	}
 }
 
@@ -49,7 +48,10 @@ func (s *Service) CreateOrUpdateUser(ctx context.Context, googleUser *services.G
 				return nil, fmt.Errorf("failed to create user: %w", err)
 			}
 
-			s.logger.Infof("Created new user: %s (%s)", newUser.Name, newUser.Email.String())
+			logging.FromContext(ctx).WithFields(logrus.Fields{
+				logging.FieldUserID: newUser.ID,
+				"email":             newUser.Email.String(),
+			}).Info("Created new user")
 			return newUser, nil
 		}
 		return nil, fmt.Errorf("failed to find user: %w", err)
@@ -61,7 +63,10 @@ func (s *Service) CreateOrUpdateUser(ctx context.Context, googleUser *services.G
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
-	s.logger.Infof("Updated user: %s (%s)", existingUser.Name, existingUser.Email.String())
+	logging.FromContext(ctx).WithFields(logrus.Fields{
+		logging.FieldUserID: existingUser.ID,
+		"email":             existingUser.Email.String(),
+	}).Info("Updated user")
 	return existingUser, nil
 }
 
@@ -112,6 +117,6 @@ func (s *Service) DeleteUser(ctx context.Context, id int) error {
 	if err := s.userRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
-	s.logger.Infof("Deleted user with ID: %d", id)
+	logging.FromContext(ctx).WithField(logging.FieldUserID, id).Info("Deleted user")
 	return nil
 }
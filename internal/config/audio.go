@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+const (
+	AudioPreprocessorFFmpeg      = "ffmpeg"
+	AudioPreprocessorPassthrough = "passthrough"
+)
+
+type AudioConfig struct {
+	Preprocessor string
+	FFmpegPath   string
+	FFprobePath  string
+	TrimSilence  bool
+	Speed        float64
+	Timeout      time.Duration
+}
+
+func NewAudioConfig() *AudioConfig {
+	cfg := &AudioConfig{
+		Preprocessor: os.Getenv("AUDIO_PREPROCESSOR"),
+		FFmpegPath:   os.Getenv("FFMPEG_PATH"),
+		FFprobePath:  os.Getenv("FFPROBE_PATH"),
+		TrimSilence:  os.Getenv("AUDIO_TRIM_SILENCE") == "true",
+		Speed:        constants.DefaultPlaybackSpeed,
+		Timeout:      durationFromEnv("AUDIO_PREPROCESS_TIMEOUT", constants.AudioPreprocessTimeout),
+	}
+
+	if cfg.FFmpegPath == "" {
+		cfg.FFmpegPath = constants.DefaultFFmpegPath
+	}
+	if cfg.FFprobePath == "" {
+		cfg.FFprobePath = constants.DefaultFFprobePath
+	}
+	if speed, err := strconv.ParseFloat(os.Getenv("AUDIO_SPEED"), 64); err == nil && speed >= 1 && speed <= constants.MaxPlaybackSpeed {
+		cfg.Speed = speed
+	}
+
+	return cfg
+}
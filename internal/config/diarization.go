@@ -0,0 +1,27 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+const (
+	DiarizationProviderHTTP = "http"
+	DiarizationProviderFake = "fake"
+)
+
+type DiarizationConfig struct {
+	Provider string
+	URL      string
+	Timeout  time.Duration
+}
+
+func NewDiarizationConfig() *DiarizationConfig {
+	return &DiarizationConfig{
+		Provider: os.Getenv("DIARIZATION_PROVIDER"),
+		URL:      os.Getenv("DIARIZATION_URL"),
+		Timeout:  durationFromEnv("DIARIZATION_TIMEOUT", constants.DiarizationTimeout),
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+type DocumentConfig struct {
+	PDFToTextPath string
+	Timeout       time.Duration
+}
+
+func NewDocumentConfig() *DocumentConfig {
+	cfg := &DocumentConfig{
+		PDFToTextPath: os.Getenv("PDFTOTEXT_PATH"),
+		Timeout:       durationFromEnv("DOCUMENT_EXTRACT_TIMEOUT", constants.DocumentExtractTimeout),
+	}
+
+	if cfg.PDFToTextPath == "" {
+		cfg.PDFToTextPath = constants.DefaultPDFToTextPath
+	}
+
+	return cfg
+}
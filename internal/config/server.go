@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+// ServerConfig holds the HTTP listener settings. Only the header and idle
+// timeouts are set on http.Server: a server-wide read or write timeout would
+// cut off uploads and conversions that legitimately take minutes. Read and
+// write timeouts are applied per route instead, with ReadTimeout/WriteTimeout
+// for ordinary requests and the Conversion* pair for routes that stream
+// uploads, convert recordings or serve stored files.
+type ServerConfig struct {
+	Addr                   string
+	ReadHeaderTimeout      time.Duration
+	ReadTimeout            time.Duration
+	WriteTimeout           time.Duration
+	ConversionReadTimeout  time.Duration
+	ConversionWriteTimeout time.Duration
+	IdleTimeout            time.Duration
+	DrainTimeout           time.Duration
+	ReadinessDelay         time.Duration
+}
+
+func NewServerConfig() *ServerConfig {
+	return &ServerConfig{
+		Addr:                   os.Getenv("HTTP_ADDR"),
+		ReadHeaderTimeout:      durationFromEnv("HTTP_READ_HEADER_TIMEOUT", constants.HTTPReadHeaderTimeout),
+		ReadTimeout:            durationFromEnv("HTTP_READ_TIMEOUT", constants.HTTPReadTimeout),
+		WriteTimeout:           durationFromEnv("HTTP_WRITE_TIMEOUT", constants.HTTPWriteTimeout),
+		ConversionReadTimeout:  durationFromEnv("HTTP_CONVERSION_READ_TIMEOUT", constants.HTTPConversionReadTimeout),
+		ConversionWriteTimeout: durationFromEnv("HTTP_CONVERSION_WRITE_TIMEOUT", constants.HTTPConversionWriteTimeout),
+		IdleTimeout:            durationFromEnv("HTTP_IDLE_TIMEOUT", constants.HTTPIdleTimeout),
+		DrainTimeout:           durationFromEnv("SHUTDOWN_DRAIN_TIMEOUT", constants.ShutdownDrainTimeout),
+		ReadinessDelay:         durationFromEnv("SHUTDOWN_READINESS_DELAY", constants.ShutdownReadinessDelay),
+	}
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return fallback
+	}
+	return d
+}
+
+func intFromEnv(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromEnvFallsBackOnInvalidValues(t *testing.T) {
+	tests := []struct {
+		value        string
+		wantInt      int
+		wantDuration time.Duration
+	}{
+		{"", 4, time.Minute},
+		{"8", 8, time.Minute},
+		{"0", 4, 0},
+		{"-2", 4, time.Minute},
+		{"many", 4, time.Minute},
+		{"90s", 4, 90 * time.Second},
+		{"-5s", 4, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("CONFIG_TEST_VALUE", tt.value)
+			if got := intFromEnv("CONFIG_TEST_VALUE", 4); got != tt.wantInt {
+				t.Errorf("intFromEnv(%q) = %d, want %d", tt.value, got, tt.wantInt)
+			}
+			if got := durationFromEnv("CONFIG_TEST_VALUE", time.Minute); got != tt.wantDuration {
+				t.Errorf("durationFromEnv(%q) = %s, want %s", tt.value, got, tt.wantDuration)
+			}
+		})
+	}
+}
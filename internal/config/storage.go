@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+const (
+	StorageProviderLocal = "local"
+	StorageProviderS3    = "s3"
+)
+
+type StorageConfig struct {
+	Provider          string
+	Dir               string
+	PublicURL         string
+	SigningKey        string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PathStyle       bool
+	Timeout           time.Duration
+	URLTTL            time.Duration
+	Retention         time.Duration
+	SourceRetention   time.Duration
+}
+
+func NewStorageConfig() *StorageConfig {
+	cfg := &StorageConfig{
+		Provider:          os.Getenv("STORAGE_PROVIDER"),
+		Dir:               os.Getenv("STORAGE_DIR"),
+		PublicURL:         os.Getenv("STORAGE_PUBLIC_URL"),
+		SigningKey:        os.Getenv("STORAGE_SIGNING_KEY"),
+		S3Endpoint:        os.Getenv("S3_ENDPOINT"),
+		S3Region:          os.Getenv("S3_REGION"),
+		S3Bucket:          os.Getenv("S3_BUCKET"),
+		S3AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		Timeout:           durationFromEnv("STORAGE_TIMEOUT", constants.StorageTimeout),
+		URLTTL:            durationFromEnv("STORAGE_URL_TTL", constants.StorageURLTTL),
+		Retention:         durationFromEnv("STORAGE_RETENTION", constants.ArtifactRetention),
+		SourceRetention:   durationFromEnv("STORAGE_SOURCE_RETENTION", constants.SourceRetention),
+	}
+
+	if cfg.Provider == "" {
+		cfg.Provider = StorageProviderLocal
+	}
+	if cfg.Dir == "" {
+		cfg.Dir = filepath.Join(os.TempDir(), constants.StorageDirName)
+	}
+	if cfg.S3Region == "" {
+		cfg.S3Region = constants.DefaultS3Region
+	}
+	if pathStyle, err := strconv.ParseBool(os.Getenv("S3_FORCE_PATH_STYLE")); err == nil {
+		cfg.S3PathStyle = pathStyle
+	}
+
+	return cfg
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+type UploadConfig struct {
+	Dir           string
+	TTL           time.Duration
+	MaxConcurrent int
+}
+
+func NewUploadConfig() *UploadConfig {
+	cfg := &UploadConfig{
+		Dir:           os.Getenv("UPLOAD_DIR"),
+		TTL:           durationFromEnv("UPLOAD_TTL", constants.UploadTTL),
+		MaxConcurrent: intFromEnv("UPLOAD_MAX_CONCURRENT", constants.MaxConcurrentUploads),
+	}
+
+	if cfg.Dir == "" {
+		cfg.Dir = filepath.Join(os.TempDir(), constants.UploadDirName)
+	}
+
+	return cfg
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+type VideoConfig struct {
+	SceneThreshold float64
+	MaxSlides      int
+	Timeout        time.Duration
+}
+
+func NewVideoConfig() *VideoConfig {
+	cfg := &VideoConfig{
+		SceneThreshold: constants.DefaultSceneThreshold,
+		MaxSlides:      constants.DefaultMaxSlides,
+		Timeout:        durationFromEnv("VIDEO_SLIDES_TIMEOUT", constants.SlideExtractTimeout),
+	}
+
+	if threshold, err := strconv.ParseFloat(os.Getenv("VIDEO_SCENE_THRESHOLD"), 64); err == nil && threshold > 0 && threshold < 1 {
+		cfg.SceneThreshold = threshold
+	}
+	if slides, err := strconv.Atoi(os.Getenv("VIDEO_MAX_SLIDES")); err == nil && slides > 0 && slides <= constants.MaxSlides {
+		cfg.MaxSlides = slides
+	}
+
+	return cfg
+}
@@ -17,16 +17,44 @@ const (
 	HeaderReferrerPolicy      = "Referrer-Policy"
 	HeaderPermissionsPolicy   = "Permissions-Policy"
 
-	HeaderAccessControlAllowOrigin  = "Access-Control-Allow-Origin"
-	HeaderAccessControlAllowMethods = "Access-Control-Allow-Methods"
-	HeaderAccessControlAllowHeaders = "Access-Control-Allow-Headers"
-	HeaderAccessControlMaxAge       = "Access-Control-Max-Age"
+	HeaderAccessControlAllowOrigin   = "Access-Control-Allow-Origin"
+	HeaderAccessControlAllowMethods  = "Access-Control-Allow-Methods"
+	HeaderAccessControlAllowHeaders  = "Access-Control-Allow-Headers"
+	HeaderAccessControlMaxAge        = "Access-Control-Max-Age"
+	HeaderAccessControlExposeHeaders = "Access-Control-Expose-Headers"
+	HeaderAccessControlRequestMethod = "Access-Control-Request-Method"
 
 	HeaderContentDisposition = "Content-Disposition"
+	HeaderXJobID             = "X-Job-ID"
+	HeaderXConspectID        = "X-Conspect-ID"
+	HeaderXPagesTarget       = "X-Pages-Target"
+	HeaderXPagesActual       = "X-Pages-Actual"
+	HeaderRetryAfter         = "Retry-After"
+	HeaderAcceptLanguage     = "Accept-Language"
+	HeaderContentLanguage    = "Content-Language"
+	HeaderLocation           = "Location"
+	HeaderCacheControl       = "Cache-Control"
+
+	HeaderTusResumable         = "Tus-Resumable"
+	HeaderTusVersion           = "Tus-Version"
+	HeaderTusExtension         = "Tus-Extension"
+	HeaderTusMaxSize           = "Tus-Max-Size"
+	HeaderTusChecksumAlgorithm = "Tus-Checksum-Algorithm"
+	HeaderUploadLength         = "Upload-Length"
+	HeaderUploadOffset         = "Upload-Offset"
+	HeaderUploadMetadata       = "Upload-Metadata"
+	HeaderUploadChecksum       = "Upload-Checksum"
 
 	ContentTypeJSON        = "application/json"
+	ContentTypeProblemJSON = "application/problem+json"
 	ContentTypePDF         = "application/pdf"
 	ContentTypeOctetStream = "application/octet-stream"
+	ContentTypeText        = "text/plain; charset=utf-8"
+	ContentTypeHTML        = "text/html; charset=utf-8"
+	ContentTypeSRT         = "application/x-subrip"
+	ContentTypeVTT         = "text/vtt; charset=utf-8"
+	ContentTypeDiff        = "text/x-diff; charset=utf-8"
+	ContentTypeTusChunk    = "application/offset+octet-stream"
 
 	XContentTypeOptionsNoSniff = "nosniff"
 	XFrameOptionsDeny          = "DENY"
@@ -36,26 +64,72 @@ const (
 	ReferrerPolicyStrictOrigin = "strict-origin-when-cross-origin"
 	PermissionsPolicyRestrict  = "geolocation=(), microphone=(), camera=()"
 
-	CORSAllowMethods = "POST, GET, OPTIONS"
-	CORSAllowHeaders = "Content-Type, Authorization"
-	CORSMaxAge       = "86400"
+	CORSAllowMethods  = "POST, GET, PUT, PATCH, HEAD, DELETE, OPTIONS"
+	CORSAllowHeaders  = "Content-Type, Authorization, Tus-Resumable, Upload-Length, Upload-Offset, Upload-Metadata, Upload-Checksum"
+	CORSExposeHeaders = "Location, Tus-Resumable, Tus-Version, Tus-Extension, Tus-Max-Size, Tus-Checksum-Algorithm, Upload-Length, Upload-Offset, Upload-Metadata"
+	CORSMaxAge        = "86400"
 
 	MethodGET     = "GET"
 	MethodPOST    = "POST"
 	MethodOPTIONS = "OPTIONS"
+	MethodPATCH   = "PATCH"
+	MethodHEAD    = "HEAD"
+
+	TusVersion    = "1.0.0"
+	TusExtensions = "creation,termination,checksum"
+	CacheNoStore  = "no-store"
+	UploadsPath   = "/uploads"
+	FilesPath     = "/files"
 
 	FormFieldFile  = "file"
 	FormFieldPages = "pages"
 	FormFieldNotes = "notes"
 
-	TempFilePattern   = "audio-*.mp3"
+	FormFieldSourceLanguage = "source_language"
+	FormFieldTargetLanguage = "target_language"
+	FormFieldBilingual      = "bilingual"
+	FormFieldStyle          = "style"
+	FormFieldStyleParams    = "style_params"
+	FormFieldDiarize        = "diarize"
+	FormFieldSpeakers       = "speakers"
+	FormFieldGlossary       = "glossary"
+	FormFieldSlides         = "slides"
+	FormFieldUploadID       = "upload_id"
+	FormFieldLayout         = "layout"
+	FormFieldRevision       = "revision"
+	FormFieldContent        = "content"
+	FormFieldText           = "text"
+
+	TempFilePattern   = "upload-*"
 	OutputPDFFileName = "notes.pdf"
+	TextFileName      = "text.txt"
 	AttachmentPrefix  = "attachment; filename="
 
+	TranscriptFormatTXT  = "txt"
+	TranscriptFormatSRT  = "srt"
+	TranscriptFormatVTT  = "vtt"
+	TranscriptFormatJSON = "json"
+
+	DiffFormatJSON    = "json"
+	DiffFormatUnified = "unified"
+	DiffContextLines  = 3
+
+	QueryParamFormat   = "format"
+	QueryParamJobID    = "job_id"
+	QueryParamRevision = "revision"
+	QueryParamFrom     = "from"
+	QueryParamTo       = "to"
+
 	MaxBodySize       = 110 * 1024 * 1024
+	MaxUploadBodySize = 1034 * 1024 * 1024
+	MaxFormValueSize  = 64 * 1024
+	MaxTextValueSize  = 1024 * 1024
 	RateLimitRequests = 10
 	RateLimitWindow   = 1 * time.Minute
 
+	CookieLanguageName = "lang"
+	QueryParamLanguage = "lang"
+
 	CookieMaxAge   = 24 * 60 * 60
 	CookieHttpOnly = true
 	CookieSecure   = false
@@ -0,0 +1,53 @@
+package constants
+
+import "time"
+
+const (
+	HTTPReadHeaderTimeout      = 10 * time.Second
+	HTTPReadTimeout            = 1 * time.Minute
+	HTTPWriteTimeout           = 2 * time.Minute
+	HTTPConversionReadTimeout  = 5 * time.Minute
+	HTTPConversionWriteTimeout = 11 * time.Minute
+	HTTPIdleTimeout            = 2 * time.Minute
+
+	ShutdownDrainTimeout   = 5 * time.Minute
+	ShutdownReadinessDelay = 5 * time.Second
+	ShutdownAbortGrace     = 10 * time.Second
+
+	HealthCheckTimeout = 3 * time.Second
+	HealthCacheTTL     = 10 * time.Second
+	HealthAICacheTTL   = 1 * time.Minute
+
+	DiarizationTimeout        = 5 * time.Minute
+	FakeDiarizationSpeakers   = 2
+	FakeDiarizationTurnLength = 30 * time.Second
+
+	AudioPreprocessTimeout = 10 * time.Minute
+	DefaultFFmpegPath      = "ffmpeg"
+	DefaultFFprobePath     = "ffprobe"
+	DefaultPlaybackSpeed   = 1.0
+	MaxPlaybackSpeed       = 2.0
+
+	SlideExtractTimeout   = 5 * time.Minute
+	DefaultSceneThreshold = 0.3
+	DefaultMaxSlides      = 20
+	MaxSlides             = 50
+
+	DocumentExtractTimeout = 1 * time.Minute
+	DefaultPDFToTextPath   = "pdftotext"
+
+	UploadDirName         = "conspect-uploads"
+	UploadTTL             = 24 * time.Hour
+	UploadCleanupInterval = 15 * time.Minute
+	MaxConcurrentUploads  = 8
+	UploadBusyRetryAfter  = 30 * time.Second
+
+	StorageDirName          = "conspect-storage"
+	StorageURLTTL           = 15 * time.Minute
+	StorageTimeout          = 10 * time.Minute
+	ArtifactRetention       = 30 * 24 * time.Hour
+	SourceRetention         = 7 * 24 * time.Hour
+	ArtifactCleanupInterval = 1 * time.Hour
+	CacheCleanupInterval    = 1 * time.Hour
+	DefaultS3Region         = "us-east-1"
+)
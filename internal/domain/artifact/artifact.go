@@ -0,0 +1,75 @@
+package artifact
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+const keyBytes = 8
+
+type Kind string
+
+const (
+	KindSource     Kind = "source"
+	KindTranscript Kind = "transcript"
+	KindDocument   Kind = "document"
+)
+
+var Kinds = []Kind{KindSource, KindTranscript, KindDocument}
+
+type Artifact struct {
+	ID          int
+	ConspectID  int
+	Kind        Kind
+	Key         string
+	Filename    string
+	ContentType string
+	Size        int64
+	CreatedAt   time.Time
+	ExpiresAt   *time.Time
+}
+
+func NewArtifact(conspectID int, kind Kind, filename, contentType string, size int64, retention time.Duration) *Artifact {
+	bytes := make([]byte, keyBytes)
+	rand.Read(bytes)
+
+	now := time.Now()
+	a := &Artifact{
+		ConspectID:  conspectID,
+		Kind:        kind,
+		Key:         fmt.Sprintf("conspects/%d/%s/%s%s", conspectID, kind, hex.EncodeToString(bytes), strings.ToLower(path.Ext(filename))),
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   now,
+	}
+	if retention > 0 {
+		expiresAt := now.Add(retention)
+		a.ExpiresAt = &expiresAt
+	}
+	return a
+}
+
+func ParseKind(value string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == value {
+			return kind, nil
+		}
+	}
+	return "", ErrUnknownKind
+}
+
+func (a *Artifact) IsExpired(now time.Time) bool {
+	return a.ExpiresAt != nil && !now.Before(*a.ExpiresAt)
+}
+
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "..") {
+		return ErrInvalidKey
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package artifact
+
+import "errors"
+
+var (
+	ErrArtifactNotFound = errors.New("artifact not found")
+	ErrObjectNotFound   = errors.New("stored object not found")
+	ErrInvalidKey       = errors.New("invalid object key")
+	ErrUnknownKind      = errors.New("unknown artifact kind")
+)
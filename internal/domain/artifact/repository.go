@@ -0,0 +1,13 @@
+package artifact
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	FindByConspect(ctx context.Context, conspectID int) ([]*Artifact, error)
+	FindExpired(ctx context.Context, before time.Time) ([]*Artifact, error)
+	Create(ctx context.Context, artifact *Artifact) error
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,14 @@
+package artifact
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key, filename string, ttl time.Duration) (string, error)
+}
@@ -0,0 +1,64 @@
+package conspect
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+type TranscriptKey struct {
+	AudioHash string
+	Model     string
+	Language  Language
+	Prompt    string
+	Diarize   bool
+}
+
+func (k TranscriptKey) Hash() string {
+	return hashFields("transcript", k.AudioHash, k.Model, k.Language.Code(), k.Prompt, strconv.FormatBool(k.Diarize))
+}
+
+type SummaryKey struct {
+	TranscriptHash string
+	PromptVersion  string
+	Model          string
+	Params         string
+}
+
+func (k SummaryKey) Hash() string {
+	return hashFields("summary", k.TranscriptHash, k.PromptVersion, k.Model, k.Params)
+}
+
+type CachedTranscript struct {
+	Transcript       Transcript
+	DetectedLanguage Language
+	Speakers         Speakers
+	Video            bool
+}
+
+type CachedSummary struct {
+	Summary       string
+	SourceSummary string
+	ActualPages   int
+	Citations     []Citation
+	Glossary      []GlossaryEntry
+}
+
+type Cache interface {
+	FindTranscript(ctx context.Context, key string) (*CachedTranscript, error)
+	SaveTranscript(ctx context.Context, key string, transcript *CachedTranscript) error
+	FindSummary(ctx context.Context, key string) (*CachedSummary, error)
+	SaveSummary(ctx context.Context, key string, summary *CachedSummary) error
+	DeleteUnusedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+func hashFields(fields ...string) string {
+	h := sha256.New()
+	for _, field := range fields {
+		fmt.Fprintf(h, "%d:%s;", len(field), field)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,159 @@
+package conspect
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	citationPattern  = regexp.MustCompile(`\[\[\s*S(\d+)(?:\s*[-–]\s*S?(\d+))?\s*\]\]`)
+	segmentIDPattern = regexp.MustCompile(`\[S\d+(?:\s+[\d:]+)?\]\s?`)
+)
+
+type Citation struct {
+	Paragraph    int
+	FirstSegment int
+	LastSegment  int
+	Start        time.Duration
+	End          time.Duration
+}
+
+func (c Citation) Label() string {
+	return formatClock(c.Start)
+}
+
+func StripCitations(text string) string {
+	text, _ = ExtractCitations(text, nil)
+	return text
+}
+
+func ExtractCitations(text string, segments []Segment) (string, []Citation) {
+	lines := strings.Split(text, "\n")
+	var citations []Citation
+
+	paragraph := 0
+	for i, line := range lines {
+		matches := citationPattern.FindAllStringSubmatch(line, -1)
+		line = citationPattern.ReplaceAllString(line, "")
+		line = strings.TrimRight(segmentIDPattern.ReplaceAllString(line, ""), " ")
+		lines[i] = line
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if citation, ok := resolveCitation(matches, segments); ok {
+			citation.Paragraph = paragraph
+			citations = append(citations, citation)
+		}
+		paragraph++
+	}
+
+	return strings.Join(lines, "\n"), citations
+}
+
+func resolveCitation(matches [][]string, segments []Segment) (Citation, bool) {
+	if len(matches) == 0 || len(segments) == 0 {
+		return Citation{}, false
+	}
+
+	first, last := -1, -1
+	for _, match := range matches {
+		from, _ := strconv.Atoi(match[1])
+		to := from
+		if match[2] != "" {
+			to, _ = strconv.Atoi(match[2])
+		}
+		if to < from {
+			from, to = to, from
+		}
+		if first == -1 || from < first {
+			first = from
+		}
+		if to > last {
+			last = to
+		}
+	}
+
+	if first >= len(segments) {
+		return Citation{}, false
+	}
+	last = min(last, len(segments)-1)
+
+	return Citation{
+		FirstSegment: first,
+		LastSegment:  last,
+		Start:        segments[first].Start,
+		End:          segments[last].End,
+	}, true
+}
+
+func (t Transcript) Annotated() string {
+	if len(t.Segments) == 0 {
+		return t.Text
+	}
+
+	var b strings.Builder
+	for _, segment := range t.Segments {
+		b.WriteString("[S")
+		b.WriteString(strconv.Itoa(segment.Position))
+		b.WriteString(" ")
+		b.WriteString(formatClock(segment.Start))
+		b.WriteString("] ")
+		if segment.Speaker != "" {
+			b.WriteString("{")
+			b.WriteString(segment.Speaker)
+			b.WriteString("}: ")
+		}
+		b.WriteString(segment.Text)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func CitationLabels(citations []Citation) map[int]string {
+	labels := make(map[int]string, len(citations))
+	for _, citation := range citations {
+		labels[citation.Paragraph] = citation.Label()
+	}
+	return labels
+}
+
+func RemapCitations(citations []Citation, from, to string) []Citation {
+	byParagraph := make(map[int]Citation, len(citations))
+	for _, citation := range citations {
+		byParagraph[citation.Paragraph] = citation
+	}
+
+	positions := make(map[string][]int)
+	for i, paragraph := range Paragraphs(from) {
+		positions[paragraph] = append(positions[paragraph], i)
+	}
+
+	var remapped []Citation
+	for i, paragraph := range Paragraphs(to) {
+		queue := positions[paragraph]
+		if len(queue) == 0 {
+			continue
+		}
+		positions[paragraph] = queue[1:]
+
+		if citation, ok := byParagraph[queue[0]]; ok {
+			citation.Paragraph = i
+			remapped = append(remapped, citation)
+		}
+	}
+	return remapped
+}
+
+func Paragraphs(text string) []string {
+	var paragraphs []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paragraphs = append(paragraphs, line)
+		}
+	}
+	return paragraphs
+}
@@ -0,0 +1,157 @@
+package conspect
+
+import (
+	"crypto/subtle"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+)
+
+type Conspect struct {
+	ID               int
+	UserID           *int
+	ParentID         *int
+	JobID            string
+	Status           Status
+	SourceFilename   string
+	Audio            AudioInfo
+	SourceLanguage   Language
+	TargetLanguage   Language
+	DetectedLanguage Language
+	Bilingual        bool
+	Style            string
+	StyleParams      map[string]string
+	Layout           string
+	Pages            int
+	ActualPages      int
+	Notes            string
+	Summary          string
+	SourceSummary    string
+	Revision         int
+	Transcript       Transcript
+	Citations        []Citation
+	Diarize          bool
+	Speakers         Speakers
+	GlossaryID       *int
+	Glossary         []GlossaryEntry
+	ErrorCode        string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	CompletedAt      *time.Time
+}
+
+func NewConspect(userID *int, jobID, sourceFilename string, audio AudioInfo, opts Options) *Conspect {
+	now := time.Now()
+	conspect := &Conspect{
+		UserID:         userID,
+		JobID:          jobID,
+		Status:         StatusProcessing,
+		SourceFilename: sourceFilename,
+		Audio:          audio,
+		SourceLanguage: opts.SourceLanguage,
+		TargetLanguage: opts.TargetLanguage,
+		Bilingual:      opts.Bilingual,
+		Style:          opts.Style,
+		StyleParams:    opts.StyleParams,
+		Diarize:        opts.Diarize,
+		Pages:          opts.Pages,
+		Notes:          opts.Notes,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if opts.GlossaryID != 0 {
+		glossaryID := opts.GlossaryID
+		conspect.GlossaryID = &glossaryID
+	}
+	return conspect
+}
+
+func (c *Conspect) NewVersion(jobID string, opts Options, layout string) *Conspect {
+	version := NewConspect(c.UserID, jobID, c.SourceFilename, c.Audio, opts)
+	parentID := c.ID
+	version.ParentID = &parentID
+	version.Layout = layout
+	return version
+}
+
+func (c *Conspect) Options() Options {
+	opts := Options{
+		Pages:          c.Pages,
+		Notes:          c.Notes,
+		SourceLanguage: c.SourceLanguage,
+		TargetLanguage: c.TargetLanguage,
+		Bilingual:      c.Bilingual,
+		Style:          c.Style,
+		StyleParams:    c.StyleParams,
+		Diarize:        c.Diarize,
+	}
+	if c.GlossaryID != nil {
+		opts.GlossaryID = *c.GlossaryID
+	}
+	return opts
+}
+
+func (c *Conspect) Result() Result {
+	return Result{
+		Transcript:       c.Transcript,
+		DetectedLanguage: c.DetectedLanguage,
+		TargetLanguage:   c.TargetLanguage,
+		Summary:          c.Summary,
+		SourceSummary:    c.SourceSummary,
+		TargetPages:      c.Pages,
+		ActualPages:      c.ActualPages,
+		Citations:        c.Citations,
+		Speakers:         c.Speakers,
+		Glossary:         c.Glossary,
+	}
+}
+
+func (c *Conspect) IsCompleted() bool {
+	return c.Status == StatusCompleted
+}
+
+func (c *Conspect) Complete(result Result) {
+	now := time.Now()
+	c.Status = StatusCompleted
+	c.DetectedLanguage = result.DetectedLanguage
+	c.TargetLanguage = result.TargetLanguage
+	c.Summary = result.Summary
+	c.SourceSummary = result.SourceSummary
+	c.ActualPages = result.ActualPages
+	c.Transcript = result.Transcript
+	c.Citations = result.Citations
+	c.Speakers = result.Speakers
+	c.Glossary = result.Glossary
+	c.UpdatedAt = now
+	c.CompletedAt = &now
+}
+
+func (c *Conspect) ApplyRevision(revision *Revision) {
+	c.Summary = revision.Content
+	c.Citations = revision.Citations
+	c.Revision = revision.Number
+	c.UpdatedAt = revision.CreatedAt
+}
+
+func (c *Conspect) Fail(errorCode string) {
+	c.Status = StatusFailed
+	c.ErrorCode = errorCode
+	c.UpdatedAt = time.Now()
+}
+
+func (c *Conspect) IsOwnedBy(userID int) bool {
+	return c.UserID != nil && *c.UserID == userID
+}
+
+func (c *Conspect) CanBeViewedBy(userID *int, jobID string) bool {
+	if userID != nil && c.IsOwnedBy(*userID) {
+		return true
+	}
+	return c.UserID == nil && jobID != "" && subtle.ConstantTimeCompare([]byte(jobID), []byte(c.JobID)) == 1
+}
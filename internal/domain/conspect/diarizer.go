@@ -0,0 +1,10 @@
+package conspect
+
+import (
+	"context"
+	"time"
+)
+
+type Diarizer interface {
+	Diarize(ctx context.Context, filePath string, duration time.Duration) ([]SpeakerTurn, error)
+}
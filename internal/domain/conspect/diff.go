@@ -0,0 +1,211 @@
+package conspect
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const maxDiffEdits = 2000
+
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+type DiffLine struct {
+	Op      DiffOp
+	Text    string
+	OldLine int
+	NewLine int
+}
+
+func Diff(from, to string) []DiffLine {
+	a, b := splitLines(from), splitLines(to)
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]DiffOp, 0, len(a)+len(b))
+	for range prefix {
+		ops = append(ops, DiffEqual)
+	}
+	ops = append(ops, diffOps(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for range suffix {
+		ops = append(ops, DiffEqual)
+	}
+
+	lines := make([]DiffLine, 0, len(ops))
+	x, y := 0, 0
+	for _, op := range ops {
+		switch op {
+		case DiffEqual:
+			lines = append(lines, DiffLine{Op: op, Text: a[x], OldLine: x + 1, NewLine: y + 1})
+			x++
+			y++
+		case DiffDelete:
+			lines = append(lines, DiffLine{Op: op, Text: a[x], OldLine: x + 1})
+			x++
+		case DiffInsert:
+			lines = append(lines, DiffLine{Op: op, Text: b[y], NewLine: y + 1})
+			y++
+		}
+	}
+	return lines
+}
+
+func diffOps(a, b []string) []DiffOp {
+	n, m := len(a), len(b)
+	limit := min(n+m, maxDiffEdits)
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+
+	var trace [][]int
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, slices.Clone(v[offset-d:offset+d+1]))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, n, m)
+			}
+		}
+	}
+
+	ops := make([]DiffOp, 0, n+m)
+	for range n {
+		ops = append(ops, DiffDelete)
+	}
+	for range m {
+		ops = append(ops, DiffInsert)
+	}
+	return ops
+}
+
+func backtrack(trace [][]int, n, m int) []DiffOp {
+	var ops []DiffOp
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		prevK := k - 1
+		if k == -d || (k != d && v[k-1+d] < v[k+1+d]) {
+			prevK = k + 1
+		}
+		prevX := v[prevK+d]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, DiffEqual)
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, DiffInsert)
+			y--
+		} else {
+			ops = append(ops, DiffDelete)
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, DiffEqual)
+		x--
+		y--
+	}
+
+	slices.Reverse(ops)
+	return ops
+}
+
+func UnifiedDiff(lines []DiffLine, fromName, toName string, context int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	for start := 0; start < len(lines); {
+		first := slices.IndexFunc(lines[start:], func(line DiffLine) bool { return line.Op != DiffEqual })
+		if first == -1 {
+			break
+		}
+		first += start
+
+		hunkStart := max(first-context, start)
+		hunkEnd := first
+		for i := first; i < len(lines) && i <= hunkEnd+2*context; i++ {
+			if lines[i].Op != DiffEqual {
+				hunkEnd = i
+			}
+		}
+		hunkEnd = min(hunkEnd+context, len(lines)-1)
+
+		writeHunk(&b, lines[hunkStart:hunkEnd+1], lines[:hunkStart])
+		start = hunkEnd + 1
+	}
+	return b.String()
+}
+
+func writeHunk(b *strings.Builder, hunk, before []DiffLine) {
+	var oldStart, newStart, oldCount, newCount int
+	for _, line := range before {
+		if line.Op != DiffInsert {
+			oldStart++
+		}
+		if line.Op != DiffDelete {
+			newStart++
+		}
+	}
+	for _, line := range hunk {
+		if line.Op != DiffInsert {
+			oldCount++
+		}
+		if line.Op != DiffDelete {
+			newCount++
+		}
+	}
+	if oldCount > 0 {
+		oldStart++
+	}
+	if newCount > 0 {
+		newStart++
+	}
+
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, line := range hunk {
+		prefix := " "
+		switch line.Op {
+		case DiffInsert:
+			prefix = "+"
+		case DiffDelete:
+			prefix = "-"
+		}
+		b.WriteString(prefix + line.Text + "\n")
+	}
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,42 @@
+package conspect
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+)
+
+type DocumentFormat string
+
+const (
+	DocumentText     DocumentFormat = "text"
+	DocumentMarkdown DocumentFormat = "markdown"
+	DocumentSRT      DocumentFormat = "srt"
+	DocumentVTT      DocumentFormat = "vtt"
+	DocumentDOCX     DocumentFormat = "docx"
+	DocumentPDF      DocumentFormat = "pdf"
+)
+
+var documentExtensions = map[string]DocumentFormat{
+	".txt":      DocumentText,
+	".md":       DocumentMarkdown,
+	".markdown": DocumentMarkdown,
+	".srt":      DocumentSRT,
+	".vtt":      DocumentVTT,
+	".docx":     DocumentDOCX,
+	".pdf":      DocumentPDF,
+}
+
+func DocumentFormatFromFilename(filename string) (DocumentFormat, bool) {
+	format, ok := documentExtensions[strings.ToLower(filepath.Ext(filename))]
+	return format, ok
+}
+
+func (f DocumentFormat) IsText() bool {
+	return f == DocumentText || f == DocumentMarkdown || f == DocumentSRT || f == DocumentVTT
+}
+
+type DocumentExtractor interface {
+	Extract(ctx context.Context, filePath string, format DocumentFormat) (Transcript, error)
+	Supports(format DocumentFormat) bool
+}
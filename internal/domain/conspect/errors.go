@@ -0,0 +1,20 @@
+package conspect
+
+import "errors"
+
+var (
+	ErrConspectNotFound    = errors.New("conspect not found")
+	ErrNotCompleted        = errors.New("conspect is not completed")
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+	ErrTranscriptNotFound  = errors.New("transcript not found")
+	ErrUnknownSpeaker      = errors.New("unknown speaker")
+	ErrInvalidSpeakerName  = errors.New("invalid speaker name")
+	ErrNoAudioStream       = errors.New("no audio stream")
+	ErrUndecodableAudio    = errors.New("audio cannot be decoded")
+	ErrCacheMiss           = errors.New("cache entry not found")
+	ErrRevisionNotFound    = errors.New("revision not found")
+	ErrRevisionConflict    = errors.New("revision is out of date")
+	ErrInvalidContent      = errors.New("invalid content")
+	ErrEmptyDocument       = errors.New("document contains no text")
+	ErrUnreadableDocument  = errors.New("document cannot be read")
+)
@@ -0,0 +1,6 @@
+package conspect
+
+type GlossaryEntry struct {
+	Term       string
+	Definition string
+}
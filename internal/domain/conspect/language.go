@@ -0,0 +1,197 @@
+package conspect
+
+import (
+	"strings"
+	"unicode"
+)
+
+var languageNames = map[string]string{
+	"ar": "Arabic",
+	"be": "Belarusian",
+	"bg": "Bulgarian",
+	"cs": "Czech",
+	"da": "Danish",
+	"de": "German",
+	"el": "Greek",
+	"en": "English",
+	"es": "Spanish",
+	"et": "Estonian",
+	"fi": "Finnish",
+	"fr": "French",
+	"he": "Hebrew",
+	"hi": "Hindi",
+	"hu": "Hungarian",
+	"it": "Italian",
+	"ja": "Japanese",
+	"ka": "Georgian",
+	"kk": "Kazakh",
+	"ko": "Korean",
+	"lt": "Lithuanian",
+	"lv": "Latvian",
+	"nl": "Dutch",
+	"no": "Norwegian",
+	"pl": "Polish",
+	"pt": "Portuguese",
+	"ro": "Romanian",
+	"ru": "Russian",
+	"sk": "Slovak",
+	"sr": "Serbian",
+	"sv": "Swedish",
+	"tr": "Turkish",
+	"uk": "Ukrainian",
+	"uz": "Uzbek",
+	"zh": "Chinese",
+}
+
+type Language struct {
+	code string
+}
+
+func NewLanguage(code string) (Language, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if _, ok := languageNames[code]; !ok {
+		return Language{}, ErrUnsupportedLanguage
+	}
+	return Language{code: code}, nil
+}
+
+func LanguageFromName(name string) (Language, error) {
+	for code, languageName := range languageNames {
+		if strings.EqualFold(languageName, name) {
+			return Language{code: code}, nil
+		}
+	}
+	return NewLanguage(name)
+}
+
+func (l Language) Code() string {
+	return l.code
+}
+
+func (l Language) Name() string {
+	return languageNames[l.code]
+}
+
+func (l Language) IsZero() bool {
+	return l.code == ""
+}
+
+func (l Language) String() string {
+	return l.code
+}
+
+var scriptLanguages = []struct {
+	table *unicode.RangeTable
+	code  string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Han, "zh"},
+	{unicode.Greek, "el"},
+	{unicode.Hebrew, "he"},
+	{unicode.Arabic, "ar"},
+	{unicode.Georgian, "ka"},
+	{unicode.Devanagari, "hi"},
+}
+
+var cyrillicMarkers = []struct {
+	letters string
+	code    string
+}{
+	{"іїєґ", "uk"},
+	{"ў", "be"},
+	{"әғқңөұүһ", "kk"},
+	{"ђјљњћџ", "sr"},
+}
+
+var latinStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "that", "in", "it", "with", "for"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "ein", "zu", "den"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "que", "pour", "dans"},
+	"es": {"el", "los", "las", "que", "es", "por", "una", "con", "para", "del"},
+	"it": {"il", "che", "di", "non", "per", "una", "sono", "gli", "della", "con"},
+	"pt": {"os", "que", "não", "uma", "com", "para", "do", "da", "em", "são"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "zijn"},
+	"pl": {"i", "w", "nie", "się", "na", "jest", "to", "że", "z", "do"},
+	"cs": {"a", "je", "se", "na", "že", "to", "v", "není", "jsou", "také"},
+	"sv": {"och", "att", "det", "som", "är", "en", "på", "inte", "med", "för"},
+	"tr": {"ve", "bir", "bu", "için", "ile", "da", "de", "değil", "çok", "olarak"},
+}
+
+const languageSampleSize = 20000
+
+func DetectLanguage(text string) Language {
+	if len(text) > languageSampleSize {
+		text = text[:languageSampleSize]
+	}
+
+	scripts := make(map[string]int)
+	var latin, cyrillic int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		default:
+			for _, script := range scriptLanguages {
+				if unicode.Is(script.table, r) {
+					scripts[script.code]++
+					break
+				}
+			}
+		}
+	}
+
+	best, bestCount := "", max(latin, cyrillic)
+	for code, count := range scripts {
+		if count > bestCount {
+			best, bestCount = code, count
+		}
+	}
+	switch {
+	case bestCount == 0:
+		return Language{}
+	case best != "":
+		if best == "zh" && scripts["ja"] > 0 {
+			best = "ja"
+		}
+		return Language{code: best}
+	case cyrillic >= latin:
+		return Language{code: detectCyrillic(strings.ToLower(text))}
+	default:
+		return Language{code: detectLatin(strings.ToLower(text))}
+	}
+}
+
+func detectCyrillic(text string) string {
+	for _, marker := range cyrillicMarkers {
+		if strings.ContainsAny(text, marker.letters) {
+			return marker.code
+		}
+	}
+	if !strings.ContainsAny(text, "ыэ") && strings.Contains(text, "ъ") {
+		return "bg"
+	}
+	return "ru"
+}
+
+func detectLatin(text string) string {
+	counts := make(map[string]int)
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		counts[word]++
+	}
+
+	best, bestScore := "en", 0
+	for code, stopwords := range latinStopwords {
+		score := 0
+		for _, word := range stopwords {
+			score += counts[word]
+		}
+		if score > bestScore || score > 0 && score == bestScore && code < best {
+			best, bestScore = code, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,28 @@
+package conspect
+
+type Options struct {
+	Pages          int
+	Notes          string
+	SourceLanguage Language
+	TargetLanguage Language
+	Bilingual      bool
+	Style          string
+	StyleParams    map[string]string
+	Diarize        bool
+	GlossaryID     int
+	Slides         bool
+}
+
+type Result struct {
+	Transcript       Transcript
+	DetectedLanguage Language
+	TargetLanguage   Language
+	Summary          string
+	SourceSummary    string
+	TargetPages      int
+	ActualPages      int
+	Citations        []Citation
+	Speakers         Speakers
+	Glossary         []GlossaryEntry
+	Slides           []Slide
+}
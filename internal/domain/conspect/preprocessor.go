@@ -0,0 +1,26 @@
+package conspect
+
+import (
+	"context"
+	"time"
+)
+
+type AudioInfo struct {
+	Container  string
+	Codec      string
+	Duration   time.Duration
+	SampleRate int
+	Channels   int
+	Video      bool
+}
+
+type PreparedAudio struct {
+	Path   string
+	Source AudioInfo
+	Speed  float64
+	Offset time.Duration
+}
+
+type Preprocessor interface {
+	Prepare(ctx context.Context, filePath string) (*PreparedAudio, error)
+}
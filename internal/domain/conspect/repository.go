@@ -0,0 +1,18 @@
+package conspect
+
+import "context"
+
+type Repository interface {
+	FindByID(ctx context.Context, id int) (*Conspect, error)
+	FindByJobID(ctx context.Context, jobID string) (*Conspect, error)
+	Create(ctx context.Context, conspect *Conspect) error
+	Update(ctx context.Context, conspect *Conspect) error
+	SaveTranscript(ctx context.Context, conspectID int, transcript Transcript) error
+	FindTranscript(ctx context.Context, conspectID int) (*Transcript, error)
+}
+
+type RevisionRepository interface {
+	FindByConspect(ctx context.Context, conspectID int) ([]*Revision, error)
+	FindByNumber(ctx context.Context, conspectID, number int) (*Revision, error)
+	Create(ctx context.Context, conspect *Conspect, revision *Revision) error
+}
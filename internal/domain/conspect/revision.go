@@ -0,0 +1,88 @@
+package conspect
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxContentLength = 100_000
+
+type RevisionKind string
+
+const (
+	RevisionInitial  RevisionKind = "initial"
+	RevisionEdit     RevisionKind = "edit"
+	RevisionRollback RevisionKind = "rollback"
+)
+
+type Revision struct {
+	ID           int
+	ConspectID   int
+	Number       int
+	Kind         RevisionKind
+	Content      string
+	Citations    []Citation
+	AuthorID     *int
+	RestoredFrom *int
+	CreatedAt    time.Time
+}
+
+func NewInitialRevision(conspect *Conspect) *Revision {
+	return &Revision{
+		ConspectID: conspect.ID,
+		Number:     1,
+		Kind:       RevisionInitial,
+		Content:    conspect.Summary,
+		Citations:  conspect.Citations,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func (r *Revision) Edit(content string, authorID *int) (*Revision, error) {
+	content = normalizeContent(content)
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("%w: content is empty", ErrInvalidContent)
+	}
+	if utf8.RuneCountInString(content) > maxContentLength {
+		return nil, fmt.Errorf("%w: content is longer than %d characters", ErrInvalidContent, maxContentLength)
+	}
+
+	return &Revision{
+		ConspectID: r.ConspectID,
+		Number:     r.Number + 1,
+		Kind:       RevisionEdit,
+		Content:    content,
+		Citations:  RemapCitations(r.Citations, r.Content, content),
+		AuthorID:   authorID,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func (r *Revision) Restore(target *Revision, authorID *int) *Revision {
+	restoredFrom := target.Number
+	return &Revision{
+		ConspectID:   r.ConspectID,
+		Number:       r.Number + 1,
+		Kind:         RevisionRollback,
+		Content:      target.Content,
+		Citations:    target.Citations,
+		AuthorID:     authorID,
+		RestoredFrom: &restoredFrom,
+		CreatedAt:    time.Now(),
+	}
+}
+
+func (r *Revision) SameContent(content string) bool {
+	return r.Content == normalizeContent(content)
+}
+
+func normalizeContent(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
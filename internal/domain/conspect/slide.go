@@ -0,0 +1,54 @@
+package conspect
+
+import (
+	"context"
+	"time"
+)
+
+type Slide struct {
+	At    time.Duration
+	Image []byte
+}
+
+func (s Slide) Label() string {
+	return formatClock(s.At)
+}
+
+type SlideExtractor interface {
+	ExtractSlides(ctx context.Context, filePath string) ([]Slide, error)
+}
+
+func PlaceSlides(slides []Slide, citations []Citation, paragraphs int, duration time.Duration) map[int][]Slide {
+	if len(slides) == 0 || paragraphs == 0 {
+		return nil
+	}
+
+	placed := make(map[int][]Slide)
+	for _, slide := range slides {
+		paragraph := 0
+		switch {
+		case len(citations) > 0:
+			paragraph = citedParagraph(slide.At, citations)
+		case duration > 0:
+			paragraph = min(int(int64(slide.At)*int64(paragraphs)/int64(duration)), paragraphs-1)
+		}
+		placed[paragraph] = append(placed[paragraph], slide)
+	}
+	return placed
+}
+
+func citedParagraph(at time.Duration, citations []Citation) int {
+	best, earliest := -1, 0
+	for i, citation := range citations {
+		if citation.Start < citations[earliest].Start {
+			earliest = i
+		}
+		if citation.Start <= at && (best == -1 || citation.Start > citations[best].Start) {
+			best = i
+		}
+	}
+	if best == -1 {
+		best = earliest
+	}
+	return citations[best].Paragraph
+}
@@ -0,0 +1,100 @@
+package conspect
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxSpeakerNameLength = 100
+
+var speakerRefPattern = regexp.MustCompile(`\{([A-Za-z0-9_-]+)\}`)
+
+type SpeakerTurn struct {
+	Speaker string
+	Start   time.Duration
+	End     time.Duration
+}
+
+type Speakers map[string]string
+
+func AssignSpeakers(segments []Segment, turns []SpeakerTurn) []string {
+	var order []string
+	seen := make(map[string]bool)
+
+	for i, segment := range segments {
+		best, bestOverlap := "", time.Duration(0)
+		for _, turn := range turns {
+			overlap := min(segment.End, turn.End) - max(segment.Start, turn.Start)
+			if overlap > bestOverlap {
+				best, bestOverlap = turn.Speaker, overlap
+			}
+		}
+
+		segments[i].Speaker = best
+		if best != "" && !seen[best] {
+			seen[best] = true
+			order = append(order, best)
+		}
+	}
+	return order
+}
+
+func (s Speakers) Name(label string) string {
+	if name, ok := s[label]; ok && name != "" {
+		return name
+	}
+	return label
+}
+
+func (s Speakers) Resolve(text string) string {
+	if len(s) == 0 {
+		return text
+	}
+	return speakerRefPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		label := strings.Trim(ref, "{}")
+		if _, ok := s[label]; !ok {
+			return ref
+		}
+		return s.Name(label)
+	})
+}
+
+func (s Speakers) Rename(names map[string]string) error {
+	for label, name := range names {
+		if _, ok := s[label]; !ok {
+			return ErrUnknownSpeaker
+		}
+		name = strings.TrimSpace(name)
+		if name == "" || utf8.RuneCountInString(name) > maxSpeakerNameLength {
+			return ErrInvalidSpeakerName
+		}
+	}
+
+	for label, name := range names {
+		s[label] = strings.TrimSpace(name)
+	}
+	return nil
+}
+
+func (t Transcript) HasSpeakers() bool {
+	for _, segment := range t.Segments {
+		if segment.Speaker != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Transcript) WithSpeakerNames(speakers Speakers) Transcript {
+	named := t
+	named.Segments = make([]Segment, len(t.Segments))
+	for i, segment := range t.Segments {
+		if segment.Speaker != "" {
+			segment.Speaker = speakers.Name(segment.Speaker)
+		}
+		named.Segments[i] = segment
+	}
+	return named
+}
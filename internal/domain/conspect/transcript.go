@@ -0,0 +1,135 @@
+package conspect
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Segment struct {
+	Position int
+	Start    time.Duration
+	End      time.Duration
+	Speaker  string
+	Text     string
+}
+
+type Transcript struct {
+	Text     string
+	Duration time.Duration
+	Segments []Segment
+}
+
+func (t Transcript) IsZero() bool {
+	return t.Text == "" && len(t.Segments) == 0
+}
+
+func (t Transcript) Correct(correct func(string) string) Transcript {
+	corrected := Transcript{
+		Text:     correct(t.Text),
+		Duration: t.Duration,
+		Segments: make([]Segment, len(t.Segments)),
+	}
+	for i, segment := range t.Segments {
+		segment.Text = correct(segment.Text)
+		corrected.Segments[i] = segment
+	}
+	return corrected
+}
+
+func (t Transcript) Rebase(speed float64, offset time.Duration) Transcript {
+	scale := func(d time.Duration) time.Duration {
+		return time.Duration(float64(d)*speed) + offset
+	}
+
+	rebased := Transcript{
+		Text:     t.Text,
+		Duration: scale(t.Duration),
+		Segments: make([]Segment, len(t.Segments)),
+	}
+	for i, segment := range t.Segments {
+		segment.Start = scale(segment.Start)
+		segment.End = scale(segment.End)
+		rebased.Segments[i] = segment
+	}
+	return rebased
+}
+
+func (t Transcript) Hash() string {
+	fields := []string{"transcript", t.Text, strconv.FormatInt(t.Duration.Milliseconds(), 10)}
+	for _, segment := range t.Segments {
+		fields = append(fields,
+			strconv.Itoa(segment.Position),
+			strconv.FormatInt(segment.Start.Milliseconds(), 10),
+			strconv.FormatInt(segment.End.Milliseconds(), 10),
+			segment.Speaker,
+			segment.Text,
+		)
+	}
+	return hashFields(fields...)
+}
+
+func (t Transcript) SRT() string {
+	var b strings.Builder
+	for i, segment := range t.Segments {
+		text := strings.TrimSpace(segment.Text)
+		if segment.Speaker != "" {
+			text = segment.Speaker + ": " + text
+		}
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n",
+			i+1,
+			formatTimestamp(segment.Start, ","),
+			formatTimestamp(segment.End, ","),
+			text,
+		)
+	}
+	return b.String()
+}
+
+func (t Transcript) VTT() string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, segment := range t.Segments {
+		text := strings.TrimSpace(segment.Text)
+		if segment.Speaker != "" {
+			text = "<v " + segment.Speaker + ">" + text
+		}
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n",
+			formatTimestamp(segment.Start, "."),
+			formatTimestamp(segment.End, "."),
+			text,
+		)
+	}
+	return b.String()
+}
+
+func (t Transcript) PlainText() string {
+	if len(t.Segments) == 0 {
+		return t.Text
+	}
+
+	lines := make([]string, 0, len(t.Segments))
+	for _, segment := range t.Segments {
+		text := strings.TrimSpace(segment.Text)
+		if segment.Speaker != "" {
+			text = segment.Speaker + ": " + text
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s", formatClock(segment.Start), text))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func formatTimestamp(d time.Duration, millisSeparator string) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d",
+		ms/3_600_000, ms/60_000%60, ms/1000%60, millisSeparator, ms%1000)
+}
+
+func formatClock(d time.Duration) string {
+	seconds := int(d.Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
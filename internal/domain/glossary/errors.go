@@ -0,0 +1,10 @@
+package glossary
+
+import "errors"
+
+var (
+	ErrGlossaryNotFound = errors.New("glossary not found")
+	ErrGlossaryExists   = errors.New("glossary already exists")
+	ErrInvalidName      = errors.New("invalid glossary name")
+	ErrInvalidTerms     = errors.New("invalid glossary terms")
+)
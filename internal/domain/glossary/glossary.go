@@ -0,0 +1,190 @@
+package glossary
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxNameLength       = 100
+	maxTerms            = 200
+	maxTermLength       = 100
+	maxAliases          = 10
+	maxDefinitionLength = 500
+	maxPromptLength     = 800
+)
+
+type Term struct {
+	Term       string
+	Aliases    []string
+	Definition string
+}
+
+type Glossary struct {
+	ID        int
+	UserID    int
+	Name      string
+	Terms     []Term
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewGlossary(userID int, name string, terms []Term) (*Glossary, error) {
+	now := time.Now()
+	glossary := &Glossary{
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := glossary.Update(name, terms); err != nil {
+		return nil, err
+	}
+	return glossary, nil
+}
+
+func (g *Glossary) Update(name string, terms []Term) error {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return ErrInvalidName
+	}
+
+	normalized, err := normalizeTerms(terms)
+	if err != nil {
+		return err
+	}
+
+	g.Name = name
+	g.Terms = normalized
+	g.UpdatedAt = time.Now()
+	return nil
+}
+
+func (g *Glossary) IsOwnedBy(userID int) bool {
+	return g.UserID == userID
+}
+
+func (g *Glossary) WhisperPrompt() string {
+	var prompt strings.Builder
+	for _, term := range g.Terms {
+		if prompt.Len()+len(term.Term)+2 > maxPromptLength {
+			break
+		}
+		if prompt.Len() > 0 {
+			prompt.WriteString(", ")
+		}
+		prompt.WriteString(term.Term)
+	}
+	if prompt.Len() == 0 {
+		return ""
+	}
+	return prompt.String() + "."
+}
+
+func (g *Glossary) Correct(text string) string {
+	canonical := make(map[string]string)
+	var aliases []string
+	for _, term := range g.Terms {
+		for _, alias := range term.Aliases {
+			key := strings.ToLower(alias)
+			if _, ok := canonical[key]; !ok {
+				canonical[key] = term.Term
+				aliases = append(aliases, regexp.QuoteMeta(alias))
+			}
+		}
+	}
+	if len(aliases) == 0 {
+		return text
+	}
+
+	sort.Slice(aliases, func(i, j int) bool { return len(aliases[i]) > len(aliases[j]) })
+	pattern := regexp.MustCompile(`(?i)` + strings.Join(aliases, "|"))
+
+	var corrected strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(text, -1) {
+		if !isWordBoundary(text, match[0], match[1]) {
+			continue
+		}
+		corrected.WriteString(text[last:match[0]])
+		corrected.WriteString(canonical[strings.ToLower(text[match[0]:match[1]])])
+		last = match[1]
+	}
+	corrected.WriteString(text[last:])
+	return corrected.String()
+}
+
+func (g *Glossary) Mentioned(text string) []Term {
+	lower := strings.ToLower(text)
+
+	var mentioned []Term
+	for _, term := range g.Terms {
+		if strings.Contains(lower, strings.ToLower(term.Term)) {
+			mentioned = append(mentioned, term)
+		}
+	}
+	return mentioned
+}
+
+func normalizeTerms(terms []Term) ([]Term, error) {
+	if len(terms) > maxTerms {
+		return nil, fmt.Errorf("%w: at most %d terms are allowed", ErrInvalidTerms, maxTerms)
+	}
+
+	seen := make(map[string]bool, len(terms))
+	normalized := make([]Term, 0, len(terms))
+	for _, term := range terms {
+		term.Term = strings.TrimSpace(term.Term)
+		term.Definition = strings.TrimSpace(term.Definition)
+		if term.Term == "" || utf8.RuneCountInString(term.Term) > maxTermLength {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTerms, term.Term)
+		}
+		if seen[strings.ToLower(term.Term)] {
+			return nil, fmt.Errorf("%w: duplicate term %q", ErrInvalidTerms, term.Term)
+		}
+		if utf8.RuneCountInString(term.Definition) > maxDefinitionLength {
+			return nil, fmt.Errorf("%w: definition of %q is too long", ErrInvalidTerms, term.Term)
+		}
+		if len(term.Aliases) > maxAliases {
+			return nil, fmt.Errorf("%w: %q has more than %d aliases", ErrInvalidTerms, term.Term, maxAliases)
+		}
+		seen[strings.ToLower(term.Term)] = true
+
+		aliases := make([]string, 0, len(term.Aliases))
+		for _, alias := range term.Aliases {
+			alias = strings.TrimSpace(alias)
+			if alias == "" || utf8.RuneCountInString(alias) > maxTermLength {
+				return nil, fmt.Errorf("%w: invalid alias %q of %q", ErrInvalidTerms, alias, term.Term)
+			}
+			if !strings.EqualFold(alias, term.Term) {
+				aliases = append(aliases, alias)
+			}
+		}
+		term.Aliases = aliases
+
+		normalized = append(normalized, term)
+	}
+	return normalized, nil
+}
+
+func isWordBoundary(text string, start, end int) bool {
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); isWordRune(r) {
+			return false
+		}
+	}
+	if end < len(text) {
+		if r, _ := utf8.DecodeRuneInString(text[end:]); isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
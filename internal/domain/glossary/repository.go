@@ -0,0 +1,11 @@
+package glossary
+
+import "context"
+
+type Repository interface {
+	FindByUserID(ctx context.Context, userID int) ([]*Glossary, error)
+	FindByID(ctx context.Context, id int) (*Glossary, error)
+	Create(ctx context.Context, glossary *Glossary) error
+	Update(ctx context.Context, glossary *Glossary) error
+	Delete(ctx context.Context, id int) error
+}
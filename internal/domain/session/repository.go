@@ -10,4 +10,5 @@ type Repository interface {
 	Delete(ctx context.Context, token Token) error
 	DeleteByUserID(ctx context.Context, userID int) error
 	CleanExpired(ctx context.Context) (int64, error)
+	CountActive(ctx context.Context) (int64, error)
 }
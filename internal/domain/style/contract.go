@@ -0,0 +1,67 @@
+package style
+
+import (
+	"regexp"
+	"strings"
+)
+
+const lengthTolerance = 0.5
+
+type Violation string
+
+const (
+	ViolationEmpty           Violation = "empty"
+	ViolationLists           Violation = "lists"
+	ViolationMissingCues     Violation = "missing_cues"
+	ViolationTooShort        Violation = "too_short"
+	ViolationTooLong         Violation = "too_long"
+	ViolationLeakedDelimiter Violation = "leaked_delimiter"
+)
+
+var (
+	listPattern      = regexp.MustCompile(`(?m)^\s*([-*•⦿+]|\d+[.)])\s+`)
+	delimiterPattern = regexp.MustCompile(`(?i)<\s*/?\s*(transcript|notes|source|glossary)\b[^<>]*>`)
+)
+
+func (s *Style) Check(output string, targetChars int) []Violation {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return []Violation{ViolationEmpty}
+	}
+
+	var violations []Violation
+	if delimiterPattern.MatchString(output) {
+		violations = append(violations, ViolationLeakedDelimiter)
+	}
+
+	switch s.Layout {
+	case LayoutHandwritten:
+		if listPattern.MatchString(output) {
+			violations = append(violations, ViolationLists)
+		}
+	case LayoutCornell:
+		if !strings.Contains(output, "::") {
+			violations = append(violations, ViolationMissingCues)
+		}
+	}
+
+	if targetChars > 0 {
+		length := float64(len([]rune(output)))
+		budget := float64(targetChars)
+		switch {
+		case length < budget*(1-lengthTolerance):
+			violations = append(violations, ViolationTooShort)
+		case length > budget*(1+lengthTolerance):
+			violations = append(violations, ViolationTooLong)
+		}
+	}
+
+	return violations
+}
+
+func StripDelimiters(content string) string {
+	for delimiterPattern.MatchString(content) {
+		content = delimiterPattern.ReplaceAllString(content, "")
+	}
+	return content
+}
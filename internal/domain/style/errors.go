@@ -0,0 +1,16 @@
+package style
+
+import "errors"
+
+var (
+	ErrStyleNotFound     = errors.New("style not found")
+	ErrStyleExists       = errors.New("style already exists")
+	ErrStyleReadOnly     = errors.New("style is read-only")
+	ErrInvalidName       = errors.New("invalid style name")
+	ErrInvalidLayout     = errors.New("invalid layout")
+	ErrInvalidTemplate   = errors.New("invalid prompt template")
+	ErrUnknownParameter  = errors.New("unknown style parameter")
+	ErrMissingParameter  = errors.New("missing style parameter")
+	ErrParameterTooLong  = errors.New("style parameter is too long")
+	ErrInvalidParameters = errors.New("invalid style parameters")
+)
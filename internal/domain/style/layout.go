@@ -0,0 +1,24 @@
+package style
+
+type Layout string
+
+const (
+	LayoutHandwritten Layout = "handwritten"
+	LayoutOutline     Layout = "outline"
+	LayoutCornell     Layout = "cornell"
+)
+
+func ParseLayout(value string) (Layout, error) {
+	switch layout := Layout(value); layout {
+	case LayoutHandwritten, LayoutOutline, LayoutCornell:
+		return layout, nil
+	case "":
+		return LayoutHandwritten, nil
+	default:
+		return "", ErrInvalidLayout
+	}
+}
+
+func (l Layout) String() string {
+	return string(l)
+}
@@ -0,0 +1,11 @@
+package style
+
+import "context"
+
+type Repository interface {
+	FindAll(ctx context.Context) ([]*Style, error)
+	FindByName(ctx context.Context, name string) (*Style, error)
+	Create(ctx context.Context, style *Style) error
+	Update(ctx context.Context, style *Style) error
+	Delete(ctx context.Context, name string) error
+}
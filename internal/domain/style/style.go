@@ -0,0 +1,142 @@
+package style
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	DefaultName        = "handwritten"
+	maxParameterLength = 200
+	versionBytes       = 8
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,63}$`)
+
+type Parameter struct {
+	Name        string
+	Description string
+	Default     string
+	Required    bool
+}
+
+type Style struct {
+	ID          int
+	Name        string
+	Title       string
+	Description string
+	Layout      Layout
+	Parameters  []Parameter
+	Template    string
+	Builtin     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type PromptData struct {
+	Language string
+	Pages    int
+	Params   map[string]string
+}
+
+func NewStyle(name, title, description string, layout Layout, parameters []Parameter, tmpl string) (*Style, error) {
+	if !namePattern.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+	if _, err := ParseLayout(string(layout)); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(parameters))
+	for _, param := range parameters {
+		if !namePattern.MatchString(param.Name) || seen[param.Name] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidParameters, param.Name)
+		}
+		seen[param.Name] = true
+	}
+
+	if title == "" {
+		title = name
+	}
+
+	now := time.Now()
+	style := &Style{
+		Name:        name,
+		Title:       title,
+		Description: description,
+		Layout:      layout,
+		Parameters:  parameters,
+		Template:    tmpl,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	sample := make(map[string]string, len(parameters))
+	for _, param := range parameters {
+		sample[param.Name] = param.Default
+	}
+	if _, err := style.Render(PromptData{Language: "English", Pages: 1, Params: sample}); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return style, nil
+}
+
+func (s *Style) Render(data PromptData) (string, error) {
+	tmpl, err := parse(s.Name, s.Template)
+	if err != nil {
+		return "", err
+	}
+
+	var prompt strings.Builder
+	if err := tmpl.Execute(&prompt, data); err != nil {
+		return "", fmt.Errorf("failed to render style %s: %w", s.Name, err)
+	}
+	return prompt.String(), nil
+}
+
+func (s *Style) Version() string {
+	sum := sha256.Sum256([]byte(s.Name + "\x00" + string(s.Layout) + "\x00" + s.Template))
+	return hex.EncodeToString(sum[:versionBytes])
+}
+
+func (s *Style) ResolveParams(values map[string]string) (map[string]string, error) {
+	declared := make(map[string]Parameter, len(s.Parameters))
+	for _, param := range s.Parameters {
+		declared[param.Name] = param
+	}
+
+	for name, value := range values {
+		if _, ok := declared[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownParameter, name)
+		}
+		if len([]rune(value)) > maxParameterLength {
+			return nil, fmt.Errorf("%w: %s", ErrParameterTooLong, name)
+		}
+	}
+
+	resolved := make(map[string]string, len(s.Parameters))
+	for _, param := range s.Parameters {
+		value, ok := values[param.Name]
+		if !ok || value == "" {
+			value = param.Default
+		}
+		if value == "" && param.Required {
+			return nil, fmt.Errorf("%w: %s", ErrMissingParameter, param.Name)
+		}
+		resolved[param.Name] = strings.Join(strings.Fields(StripDelimiters(value)), " ")
+	}
+	return resolved, nil
+}
+
+func parse(name, tmpl string) (*template.Template, error) {
+	parsed, err := template.New(name).Option("missingkey=zero").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,41 @@
+package upload
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"hash"
+)
+
+var ChecksumAlgorithms = []string{"sha1", "sha256", "md5"}
+
+type Checksum struct {
+	Algorithm string
+	Sum       []byte
+}
+
+func NewChecksum(algorithm string, sum []byte) (*Checksum, error) {
+	checksum := &Checksum{Algorithm: algorithm, Sum: sum}
+	if checksum.Hash() == nil {
+		return nil, ErrUnsupportedChecksum
+	}
+	return checksum, nil
+}
+
+func (c *Checksum) Matches(h hash.Hash) bool {
+	return bytes.Equal(h.Sum(nil), c.Sum)
+}
+
+func (c *Checksum) Hash() hash.Hash {
+	switch c.Algorithm {
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	case "md5":
+		return md5.New()
+	default:
+		return nil
+	}
+}
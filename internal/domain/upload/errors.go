@@ -0,0 +1,17 @@
+package upload
+
+import "errors"
+
+var (
+	ErrUploadNotFound      = errors.New("upload not found")
+	ErrUploadIncomplete    = errors.New("upload is not complete")
+	ErrUploadLocked        = errors.New("upload is locked by another request")
+	ErrInvalidID           = errors.New("invalid upload ID")
+	ErrInvalidLength       = errors.New("invalid upload length")
+	ErrUploadTooLarge      = errors.New("upload exceeds the maximum size")
+	ErrInvalidMetadata     = errors.New("invalid upload metadata")
+	ErrOffsetMismatch      = errors.New("upload offset does not match")
+	ErrExceedsLength       = errors.New("chunk exceeds the upload length")
+	ErrChecksumMismatch    = errors.New("chunk checksum does not match")
+	ErrUnsupportedChecksum = errors.New("unsupported checksum algorithm")
+)
@@ -0,0 +1,14 @@
+package upload
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	FindByID(ctx context.Context, id string) (*Upload, error)
+	FindExpired(ctx context.Context, before time.Time) ([]*Upload, error)
+	Create(ctx context.Context, upload *Upload) error
+	UpdateOffset(ctx context.Context, upload *Upload, from int64) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,21 @@
+package upload
+
+import (
+	"context"
+	"io"
+)
+
+type File interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+type Store interface {
+	Create(ctx context.Context, id string) error
+	Append(ctx context.Context, id string, offset int64, r io.Reader) (int64, error)
+	Truncate(ctx context.Context, id string, size int64) error
+	Open(ctx context.Context, id string) (File, error)
+	Delete(ctx context.Context, id string) error
+}
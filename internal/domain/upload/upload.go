@@ -0,0 +1,101 @@
+package upload
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	idBytes           = 16
+	maxFilenameLength = 255
+	maxMetadataKeys   = 20
+	maxMetadataValue  = 1024
+)
+
+var idPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+type Upload struct {
+	ID          string
+	UserID      *int
+	Filename    string
+	ContentType string
+	Metadata    map[string]string
+	Length      int64
+	Offset      int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func NewUpload(userID *int, length, maxLength int64, metadata map[string]string, ttl time.Duration) (*Upload, error) {
+	if length <= 0 {
+		return nil, ErrInvalidLength
+	}
+	if length > maxLength {
+		return nil, ErrUploadTooLarge
+	}
+
+	filename := strings.TrimSpace(firstValue(metadata, "filename", "name"))
+	if filename == "" || utf8.RuneCountInString(filename) > maxFilenameLength || strings.ContainsAny(filename, "/\\") {
+		return nil, ErrInvalidMetadata
+	}
+	if len(metadata) > maxMetadataKeys {
+		return nil, ErrInvalidMetadata
+	}
+	for _, value := range metadata {
+		if len(value) > maxMetadataValue {
+			return nil, ErrInvalidMetadata
+		}
+	}
+
+	bytes := make([]byte, idBytes)
+	rand.Read(bytes)
+
+	now := time.Now()
+	return &Upload{
+		ID:          hex.EncodeToString(bytes),
+		UserID:      userID,
+		Filename:    filename,
+		ContentType: firstValue(metadata, "filetype", "type"),
+		Metadata:    metadata,
+		Length:      length,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}, nil
+}
+
+func ValidateID(id string) error {
+	if !idPattern.MatchString(id) {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+func (u *Upload) IsComplete() bool {
+	return u.Offset == u.Length
+}
+
+func (u *Upload) Remaining() int64 {
+	return u.Length - u.Offset
+}
+
+func (u *Upload) CanBeAccessedBy(userID *int) bool {
+	if u.UserID == nil {
+		return true
+	}
+	return userID != nil && *u.UserID == *userID
+}
+
+func firstValue(metadata map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := metadata[key]; ok {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/domain/artifact"
+)
+
+type ArtifactResponse struct {
+	Kind         string     `json:"kind"`
+	Filename     string     `json:"filename"`
+	ContentType  string     `json:"content_type"`
+	Size         int64      `json:"size"`
+	URL          string     `json:"url"`
+	URLExpiresAt time.Time  `json:"url_expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+func NewArtifactResponse(a *artifact.Artifact, url string, urlExpiresAt time.Time) ArtifactResponse {
+	return ArtifactResponse{
+		Kind:         string(a.Kind),
+		Filename:     a.Filename,
+		ContentType:  a.ContentType,
+		Size:         a.Size,
+		URL:          url,
+		URLExpiresAt: urlExpiresAt,
+		CreatedAt:    a.CreatedAt,
+		ExpiresAt:    a.ExpiresAt,
+	}
+}
@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+type CitationResponse struct {
+	Paragraph    int     `json:"paragraph"`
+	Start        float64 `json:"start"`
+	End          float64 `json:"end"`
+	FirstSegment int     `json:"first_segment"`
+	LastSegment  int     `json:"last_segment"`
+}
+
+type AudioResponse struct {
+	Container       string  `json:"container"`
+	Codec           string  `json:"codec,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	SampleRate      int     `json:"sample_rate,omitempty"`
+	Channels        int     `json:"channels,omitempty"`
+	Video           bool    `json:"video"`
+}
+
+type ConspectResponse struct {
+	ID               int                `json:"id"`
+	ParentID         *int               `json:"parent_id,omitempty"`
+	Status           string             `json:"status"`
+	Style            string             `json:"style,omitempty"`
+	Layout           string             `json:"layout,omitempty"`
+	SourceFilename   string             `json:"source_filename"`
+	Audio            *AudioResponse     `json:"audio,omitempty"`
+	SourceLanguage   string             `json:"source_language,omitempty"`
+	TargetLanguage   string             `json:"target_language,omitempty"`
+	DetectedLanguage string             `json:"detected_language,omitempty"`
+	Bilingual        bool               `json:"bilingual"`
+	Diarize          bool               `json:"diarize"`
+	Speakers         map[string]string  `json:"speakers,omitempty"`
+	TargetPages      int                `json:"target_pages,omitempty"`
+	ActualPages      int                `json:"actual_pages,omitempty"`
+	Summary          string             `json:"summary,omitempty"`
+	SourceSummary    string             `json:"source_summary,omitempty"`
+	Revision         int                `json:"revision,omitempty"`
+	Paragraphs       []string           `json:"paragraphs"`
+	Citations        []CitationResponse `json:"citations"`
+	GlossaryID       *int               `json:"glossary_id,omitempty"`
+	Glossary         []GlossaryEntry    `json:"glossary"`
+	ErrorCode        string             `json:"error_code,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	CompletedAt      *time.Time         `json:"completed_at,omitempty"`
+}
+
+func NewConspectResponse(c *conspect.Conspect) *ConspectResponse {
+	response := &ConspectResponse{
+		ID:               c.ID,
+		ParentID:         c.ParentID,
+		Status:           string(c.Status),
+		Style:            c.Style,
+		Layout:           c.Layout,
+		SourceFilename:   c.SourceFilename,
+		SourceLanguage:   c.SourceLanguage.Code(),
+		TargetLanguage:   c.TargetLanguage.Code(),
+		DetectedLanguage: c.DetectedLanguage.Code(),
+		Bilingual:        c.Bilingual,
+		Diarize:          c.Diarize,
+		Speakers:         c.Speakers,
+		TargetPages:      c.Pages,
+		ActualPages:      c.ActualPages,
+		Summary:          c.Speakers.Resolve(c.Summary),
+		SourceSummary:    c.Speakers.Resolve(c.SourceSummary),
+		Revision:         c.Revision,
+		Paragraphs:       conspect.Paragraphs(c.Speakers.Resolve(c.Summary)),
+		Citations:        make([]CitationResponse, 0, len(c.Citations)),
+		GlossaryID:       c.GlossaryID,
+		Glossary:         make([]GlossaryEntry, 0, len(c.Glossary)),
+		ErrorCode:        c.ErrorCode,
+		CreatedAt:        c.CreatedAt,
+		CompletedAt:      c.CompletedAt,
+	}
+	if c.Audio.Container != "" {
+		response.Audio = &AudioResponse{
+			Container:       c.Audio.Container,
+			Codec:           c.Audio.Codec,
+			DurationSeconds: c.Audio.Duration.Seconds(),
+			SampleRate:      c.Audio.SampleRate,
+			Channels:        c.Audio.Channels,
+			Video:           c.Audio.Video,
+		}
+	}
+	if response.Paragraphs == nil {
+		response.Paragraphs = []string{}
+	}
+	for _, citation := range c.Citations {
+		response.Citations = append(response.Citations, CitationResponse{
+			Paragraph:    citation.Paragraph,
+			Start:        citation.Start.Seconds(),
+			End:          citation.End.Seconds(),
+			FirstSegment: citation.FirstSegment,
+			LastSegment:  citation.LastSegment,
+		})
+	}
+	for _, entry := range c.Glossary {
+		response.Glossary = append(response.Glossary, GlossaryEntry(entry))
+	}
+	return response
+}
+
+type RenameSpeakersRequest struct {
+	Speakers map[string]string `json:"speakers"`
+}
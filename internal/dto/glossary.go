@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/domain/glossary"
+)
+
+type GlossaryTerm struct {
+	Term       string   `json:"term"`
+	Aliases    []string `json:"aliases"`
+	Definition string   `json:"definition,omitempty"`
+}
+
+type GlossaryRequest struct {
+	Name  string         `json:"name"`
+	Terms []GlossaryTerm `json:"terms"`
+}
+
+type GlossaryResponse struct {
+	ID        int            `json:"id"`
+	Name      string         `json:"name"`
+	Terms     []GlossaryTerm `json:"terms"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type GlossaryEntry struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition"`
+}
+
+func NewGlossaryResponse(g *glossary.Glossary) *GlossaryResponse {
+	response := &GlossaryResponse{
+		ID:        g.ID,
+		Name:      g.Name,
+		Terms:     make([]GlossaryTerm, 0, len(g.Terms)),
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+	}
+	for _, t := range g.Terms {
+		term := GlossaryTerm(t)
+		if term.Aliases == nil {
+			term.Aliases = []string{}
+		}
+		response.Terms = append(response.Terms, term)
+	}
+	return response
+}
+
+func (r *GlossaryRequest) ToTerms() []glossary.Term {
+	terms := make([]glossary.Term, 0, len(r.Terms))
+	for _, t := range r.Terms {
+		terms = append(terms, glossary.Term(t))
+	}
+	return terms
+}
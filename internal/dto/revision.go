@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+type UpdateContentRequest struct {
+	Content      string `json:"content"`
+	BaseRevision *int   `json:"base_revision,omitempty"`
+}
+
+type RollbackRequest struct {
+	BaseRevision *int `json:"base_revision,omitempty"`
+}
+
+type RevisionResponse struct {
+	Number       int       `json:"number"`
+	Kind         string    `json:"kind"`
+	AuthorID     *int      `json:"author_id,omitempty"`
+	RestoredFrom *int      `json:"restored_from,omitempty"`
+	Current      bool      `json:"current"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type RevisionContentResponse struct {
+	RevisionResponse
+	Content    string   `json:"content"`
+	Paragraphs []string `json:"paragraphs"`
+}
+
+type DiffLineResponse struct {
+	Op      string `json:"op"`
+	Text    string `json:"text"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
+
+type DiffResponse struct {
+	From  int                `json:"from"`
+	To    int                `json:"to"`
+	Lines []DiffLineResponse `json:"lines"`
+}
+
+func NewRevisionResponse(c *conspect.Conspect, r *conspect.Revision) RevisionResponse {
+	return RevisionResponse{
+		Number:       r.Number,
+		Kind:         string(r.Kind),
+		AuthorID:     r.AuthorID,
+		RestoredFrom: r.RestoredFrom,
+		Current:      r.Number == c.Revision,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func NewRevisionContentResponse(c *conspect.Conspect, r *conspect.Revision) *RevisionContentResponse {
+	content := c.Speakers.Resolve(r.Content)
+	response := &RevisionContentResponse{
+		RevisionResponse: NewRevisionResponse(c, r),
+		Content:          content,
+		Paragraphs:       conspect.Paragraphs(content),
+	}
+	if response.Paragraphs == nil {
+		response.Paragraphs = []string{}
+	}
+	return response
+}
+
+func NewDiffResponse(from, to int, lines []conspect.DiffLine) *DiffResponse {
+	response := &DiffResponse{
+		From:  from,
+		To:    to,
+		Lines: make([]DiffLineResponse, 0, len(lines)),
+	}
+	for _, line := range lines {
+		response.Lines = append(response.Lines, DiffLineResponse{
+			Op:      string(line.Op),
+			Text:    line.Text,
+			OldLine: line.OldLine,
+			NewLine: line.NewLine,
+		})
+	}
+	return response
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
+)
+
+type StyleParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required"`
+}
+
+type StyleResponse struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Layout      string           `json:"layout"`
+	Parameters  []StyleParameter `json:"parameters"`
+	Builtin     bool             `json:"builtin"`
+	Template    string           `json:"template,omitempty"`
+	UpdatedAt   *time.Time       `json:"updated_at,omitempty"`
+}
+
+type StyleRequest struct {
+	Name        string           `json:"name"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Layout      string           `json:"layout"`
+	Parameters  []StyleParameter `json:"parameters"`
+	Template    string           `json:"template"`
+}
+
+func NewStyleResponse(s *style.Style, withTemplate bool) *StyleResponse {
+	response := &StyleResponse{
+		Name:        s.Name,
+		Title:       s.Title,
+		Description: s.Description,
+		Layout:      s.Layout.String(),
+		Parameters:  make([]StyleParameter, 0, len(s.Parameters)),
+		Builtin:     s.Builtin,
+	}
+	for _, p := range s.Parameters {
+		response.Parameters = append(response.Parameters, StyleParameter(p))
+	}
+	if withTemplate {
+		response.Template = s.Template
+	}
+	if !s.Builtin {
+		response.UpdatedAt = &s.UpdatedAt
+	}
+	return response
+}
+
+func (r *StyleRequest) ToStyleParameters() []style.Parameter {
+	params := make([]style.Parameter, 0, len(r.Parameters))
+	for _, p := range r.Parameters {
+		params = append(params, style.Parameter(p))
+	}
+	return params
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+
+type TranscriptSegment struct {
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Speaker string  `json:"speaker,omitempty"`
+	Text    string  `json:"text"`
+}
+
+type TranscriptResponse struct {
+	ConspectID      int                 `json:"conspect_id"`
+	Language        string              `json:"language,omitempty"`
+	DurationSeconds float64             `json:"duration_seconds"`
+	Text            string              `json:"text"`
+	Segments        []TranscriptSegment `json:"segments"`
+}
+
+func NewTranscriptResponse(c *conspect.Conspect, t *conspect.Transcript) *TranscriptResponse {
+	response := &TranscriptResponse{
+		ConspectID:      c.ID,
+		Language:        c.DetectedLanguage.Code(),
+		DurationSeconds: t.Duration.Seconds(),
+		Text:            t.Text,
+		Segments:        make([]TranscriptSegment, 0, len(t.Segments)),
+	}
+	for _, segment := range t.Segments {
+		response.Segments = append(response.Segments, TranscriptSegment{
+			Start:   segment.Start.Seconds(),
+			End:     segment.End.Seconds(),
+			Speaker: segment.Speaker,
+			Text:    segment.Text,
+		})
+	}
+	return response
+}
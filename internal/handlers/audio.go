@@ -1,81 +1,351 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
-	"os"
+	"net/textproto"
+	"strconv"
+	"sync"
 
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	artifactApp "github.com/goIdioms/conspect-generator/internal/application/artifact"
+	conspectApp "github.com/goIdioms/conspect-generator/internal/application/conspect"
+	glossaryApp "github.com/goIdioms/conspect-generator/internal/application/glossary"
+	styleApp "github.com/goIdioms/conspect-generator/internal/application/style"
+	uploadApp "github.com/goIdioms/conspect-generator/internal/application/upload"
 	c "github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/domain/artifact"
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/domain/glossary"
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
+	"github.com/goIdioms/conspect-generator/internal/domain/upload"
+	"github.com/goIdioms/conspect-generator/internal/dto"
+	"github.com/goIdioms/conspect-generator/internal/infra/uploads"
+	"github.com/goIdioms/conspect-generator/internal/logging"
 	"github.com/goIdioms/conspect-generator/internal/services"
 	"github.com/goIdioms/conspect-generator/internal/validators"
-	"github.com/sirupsen/logrus"
 )
 
 type AudioHandler struct {
 	pdfService           *services.PDFService
 	transcriptionService *services.TranscriptionService
-	logger               *logrus.Logger
+	conspectService      *conspectApp.Service
+	styleService         *styleApp.Service
+	glossaryService      *glossaryApp.Service
+	uploadService        *uploadApp.Service
+	artifactService      *artifactApp.Service
+	uploadSlots          chan struct{}
+	inFlight             sync.WaitGroup
 }
 
-func NewAudioHandler(logger *logrus.Logger) *AudioHandler {
+func NewAudioHandler(
+	pdfService *services.PDFService,
+	transcriptionService *services.TranscriptionService,
+	conspectService *conspectApp.Service,
+	styleService *styleApp.Service,
+	glossaryService *glossaryApp.Service,
+	uploadService *uploadApp.Service,
+	artifactService *artifactApp.Service,
+	maxConcurrentUploads int,
+) *AudioHandler {
 	return &AudioHandler{
-		pdfService:           services.NewPDFService(),
-		transcriptionService: services.NewTranscriptionService(),
-		logger:               logger,
+		pdfService:           pdfService,
+		transcriptionService: transcriptionService,
+		conspectService:      conspectService,
+		styleService:         styleService,
+		glossaryService:      glossaryService,
+		uploadService:        uploadService,
+		artifactService:      artifactService,
+		uploadSlots:          make(chan struct{}, maxConcurrentUploads),
 	}
 }
 
 func (h *AudioHandler) Handle(w http.ResponseWriter, r *http.Request) {
-	file, header, err := r.FormFile(c.FormFieldFile)
+	h.inFlight.Add(1)
+	defer h.inFlight.Done()
+
+	jobID := newJobID()
+	logging.AddField(r.Context(), logging.FieldJobID, jobID)
+	w.Header().Set(c.HeaderXJobID, jobID)
+
+	userID := optionalUserID(r)
+
+	form, err := h.receiveForm(w, r, validators.MaxAudioFileSize, c.MaxFormValueSize)
 	if err != nil {
-		h.logger.Warnf("Failed to get file: %v", err)
-		http.Error(w, "Файл не найден в запросе", http.StatusBadRequest)
+		apperror.Write(w, r, err)
 		return
 	}
-	defer file.Close()
+	defer form.Close()
 
-	if err := validators.ValidateAudioFile(file, header); err != nil {
-		h.logger.Warnf("File validation failed: %v", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := h.openSource(r.Context(), form, userID); err != nil {
+		apperror.Write(w, r, err)
 		return
 	}
+	file, header := form.File, form.File.Header
 
-	pages := r.FormValue(c.FormFieldPages)
-	notes := r.FormValue(c.FormFieldNotes)
+	if err := validators.ValidateAudioFile(header, file.ContentType); err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
 
-	if err := validators.ValidateRequestParams(pages, notes); err != nil {
-		h.logger.Warnf("Params validation failed: %v", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	audio, err := validators.ValidateAudioMetadata(file, header.Size)
+	if err != nil {
+		apperror.Write(w, r, err)
 		return
 	}
 
-	h.logger.Infof("Processing audio: file=%s, size=%d, pages=%s", header.Filename, header.Size, pages)
+	video, err := validators.ValidateVideo(header, audio, h.transcriptionService.SupportsVideo())
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
 
-	tmpFile, err := os.CreateTemp("", c.TempFilePattern)
+	opts, err := validators.ParseConversionOptions(validators.ConversionParams{
+		Pages:          form.Value(c.FormFieldPages),
+		Notes:          form.Value(c.FormFieldNotes),
+		SourceLanguage: form.Value(c.FormFieldSourceLanguage),
+		TargetLanguage: form.Value(c.FormFieldTargetLanguage),
+		Bilingual:      form.Value(c.FormFieldBilingual),
+		Style:          form.Value(c.FormFieldStyle),
+		StyleParams:    form.Value(c.FormFieldStyleParams),
+		Diarize:        form.Value(c.FormFieldDiarize),
+		Glossary:       form.Value(c.FormFieldGlossary),
+		Slides:         form.Value(c.FormFieldSlides),
+	})
 	if err != nil {
-		http.Error(w, "failed to create temp file", http.StatusInternalServerError)
+		apperror.Write(w, r, err)
 		return
 	}
-	defer os.Remove(tmpFile.Name())
-	defer tmpFile.Close()
 
-	if _, err := io.Copy(tmpFile, file); err != nil {
-		http.Error(w, "failed to save uploaded file", http.StatusInternalServerError)
+	if opts.Diarize && !h.transcriptionService.CanDiarize() {
+		apperror.Write(w, r, apperror.New(apperror.CodeInvalidParam, "Speaker diarization is not configured").WithField(c.FormFieldDiarize))
 		return
 	}
 
-	summary, err := h.transcriptionService.SummarizeAudio(r.Context(), tmpFile.Name(), pages, notes)
+	if opts.Slides && !h.transcriptionService.SupportsVideo() {
+		apperror.Write(w, r, apperror.New(apperror.CodeInvalidParam, "Slide extraction is not configured").WithField(c.FormFieldSlides))
+		return
+	}
+	audio.Video = video
+
+	st, gl, err := h.resolveOptions(r, &opts, userID)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	logging.FromContext(r.Context()).Infof("Processing audio: file=%s, size=%d, sha256=%s, duration=%s, video=%t, pages=%d", header.Filename, header.Size, file.SHA256, audio.Duration, audio.Video, opts.Pages)
+
+	conspect, err := h.conspectService.Start(r.Context(), userID, jobID, header.Filename, audio, opts)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apperror.Write(w, r, err)
 		return
 	}
+	w.Header().Set(c.HeaderXConspectID, strconv.Itoa(conspect.ID))
 
-	pdfBytes, err := h.pdfService.CreatePDF(summary)
+	result, err := h.transcriptionService.SummarizeAudio(r.Context(), file.Name(), file.SHA256, opts, st, gl)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.fail(w, r, conspect, err)
 		return
 	}
+
+	h.deliver(w, r, conspect, result, st.Layout, file)
+}
+
+func (h *AudioHandler) resolveOptions(r *http.Request, opts *domainConspect.Options, userID *int) (*style.Style, *glossary.Glossary, error) {
+	st, err := h.styleService.Get(r.Context(), opts.Style)
+	if err != nil {
+		if errors.Is(err, style.ErrStyleNotFound) {
+			err = apperror.Wrap(apperror.CodeInvalidParam, "Unknown style", err).
+				WithField(c.FormFieldStyle).
+				WithDetail("style", opts.Style)
+		}
+		return nil, nil, err
+	}
+	opts.Style = st.Name
+
+	if opts.StyleParams, err = st.ResolveParams(opts.StyleParams); err != nil {
+		return nil, nil, styleError(err)
+	}
+
+	var gl *glossary.Glossary
+	if opts.GlossaryID != 0 {
+		if gl, err = h.loadGlossary(r, opts.GlossaryID, userID); err != nil {
+			return nil, nil, err
+		}
+	}
+	return st, gl, nil
+}
+
+func (h *AudioHandler) deliver(w http.ResponseWriter, r *http.Request, conspect *domainConspect.Conspect, result *domainConspect.Result, layout style.Layout, source *uploads.ReceivedFile) {
+	pdfBytes, pages, err := h.renderPDF(r.Context(), result, layout)
+	if err != nil {
+		h.fail(w, r, conspect, apperror.Wrap(apperror.CodeRenderFailed, "Failed to render PDF", err))
+		return
+	}
+	result.ActualPages = pages
+
+	if err := h.conspectService.Complete(r.Context(), conspect, *result); err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	h.storeArtifacts(context.WithoutCancel(r.Context()), conspect, source, pdfBytes)
+
+	if result.TargetPages > 0 {
+		w.Header().Set(c.HeaderXPagesTarget, strconv.Itoa(result.TargetPages))
+	}
+	w.Header().Set(c.HeaderXPagesActual, strconv.Itoa(result.ActualPages))
 	w.Header().Set(c.HeaderContentType, c.ContentTypePDF)
-	w.Header().Set(c.HeaderContentDisposition, c.AttachmentPrefix+"; "+header.Filename+"="+c.OutputPDFFileName)
+	w.Header().Set(c.HeaderContentDisposition, c.AttachmentPrefix+c.OutputPDFFileName)
 	w.Write(pdfBytes)
 }
+
+func (h *AudioHandler) renderPDF(ctx context.Context, result *domainConspect.Result, layout style.Layout) ([]byte, int, error) {
+	summary := result.Speakers.Resolve(result.Summary)
+	slides := domainConspect.PlaceSlides(result.Slides, result.Citations, len(domainConspect.Paragraphs(summary)), result.Transcript.Duration)
+	if result.SourceSummary != "" {
+		return h.pdfService.CreateBilingualPDF(ctx, result.Speakers.Resolve(result.SourceSummary), summary, slides, result.Glossary)
+	}
+	return h.pdfService.CreatePDF(ctx, summary, layout, domainConspect.CitationLabels(result.Citations), slides, result.Glossary)
+}
+
+func (h *AudioHandler) storeArtifacts(ctx context.Context, conspect *domainConspect.Conspect, source *uploads.ReceivedFile, pdfBytes []byte) {
+	log := logging.FromContext(ctx)
+
+	if source != nil {
+		if _, err := source.Seek(0, io.SeekStart); err != nil {
+			log.Warnf("Failed to rewind source file: %v", err)
+		} else if _, err := h.artifactService.Save(ctx, conspect.ID, artifact.KindSource, source.Header.Filename, source.Header.Header.Get(c.HeaderContentType), source, source.Header.Size); err != nil {
+			log.Warnf("Failed to store source audio: %v", err)
+		}
+	}
+
+	if !conspect.Transcript.IsZero() {
+		transcript := conspect.Transcript.WithSpeakerNames(conspect.Speakers)
+		body, err := json.Marshal(dto.NewTranscriptResponse(conspect, &transcript))
+		if err == nil {
+			_, err = h.artifactService.Save(ctx, conspect.ID, artifact.KindTranscript, fmt.Sprintf("transcript-%d.json", conspect.ID), c.ContentTypeJSON, bytes.NewReader(body), int64(len(body)))
+		}
+		if err != nil {
+			log.Warnf("Failed to store transcript: %v", err)
+		}
+	}
+
+	if _, err := h.artifactService.Save(ctx, conspect.ID, artifact.KindDocument, c.OutputPDFFileName, c.ContentTypePDF, bytes.NewReader(pdfBytes), int64(len(pdfBytes))); err != nil {
+		log.Warnf("Failed to store rendered document: %v", err)
+	}
+}
+
+func (h *AudioHandler) receiveForm(w http.ResponseWriter, r *http.Request, maxFileSize uploads.SizeLimit, maxValueSize int64) (*uploads.Form, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		if err := r.ParseForm(); err != nil {
+			return nil, apperror.Wrap(apperror.CodeInvalidRequest, "Invalid form body", err)
+		}
+		return &uploads.Form{Values: r.PostForm}, nil
+	}
+
+	select {
+	case h.uploadSlots <- struct{}{}:
+		defer func() { <-h.uploadSlots }()
+	default:
+		w.Header().Set(c.HeaderRetryAfter, strconv.Itoa(int(c.UploadBusyRetryAfter.Seconds())))
+		return nil, apperror.New(apperror.CodeServiceUnavailable, "Too many uploads in progress")
+	}
+
+	var limit int64
+	form, err := uploads.ReceiveForm(reader, c.FormFieldFile, c.TempFilePattern, func(filename string) int64 {
+		limit = maxFileSize(filename)
+		return limit
+	}, maxValueSize)
+	switch {
+	case errors.Is(err, uploads.ErrFileTooLarge):
+		return nil, apperror.Wrap(apperror.CodeFileTooLarge, "File is too large", err).
+			WithField(c.FormFieldFile).
+			WithDetail("max_mb", limit/(1024*1024))
+	case errors.Is(err, uploads.ErrValueTooLarge), errors.Is(err, uploads.ErrDuplicateFile):
+		return nil, apperror.Wrap(apperror.CodeInvalidRequest, "Invalid form body", err)
+	}
+	return form, err
+}
+
+func (h *AudioHandler) openSource(ctx context.Context, form *uploads.Form, userID *int) error {
+	id := form.Value(c.FormFieldUploadID)
+	if id == "" {
+		if form.File == nil {
+			return apperror.New(apperror.CodeFileMissing, "file is missing from the request").WithField(c.FormFieldFile)
+		}
+		return nil
+	}
+
+	u, file, err := h.uploadService.Open(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, upload.ErrUploadNotFound) || errors.Is(err, upload.ErrUploadIncomplete) {
+			err = apperror.Wrap(apperror.CodeInvalidParam, "Unknown or unfinished upload", err).WithField(c.FormFieldUploadID)
+		}
+		return err
+	}
+	defer file.Close()
+
+	header := &multipart.FileHeader{
+		Filename: u.Filename,
+		Header:   textproto.MIMEHeader{},
+	}
+	header.Header.Set(c.HeaderContentType, u.ContentType)
+
+	received, err := uploads.ReceiveFile(file, header, c.TempFilePattern, h.uploadService.MaxSize())
+	if err != nil {
+		return err
+	}
+	form.Close()
+	form.File = received
+	return nil
+}
+
+func (h *AudioHandler) loadGlossary(r *http.Request, id int, userID *int) (*glossary.Glossary, error) {
+	if userID == nil {
+		return nil, apperror.New(apperror.CodeUnauthorized, "Glossaries require a session").WithField(c.FormFieldGlossary)
+	}
+
+	gl, err := h.glossaryService.Get(r.Context(), id, *userID)
+	if errors.Is(err, glossary.ErrGlossaryNotFound) {
+		return nil, apperror.Wrap(apperror.CodeInvalidParam, "Unknown glossary", err).WithField(c.FormFieldGlossary)
+	}
+	return gl, err
+}
+
+func (h *AudioHandler) fail(w http.ResponseWriter, r *http.Request, conspect *domainConspect.Conspect, err error) {
+	if failErr := h.conspectService.Fail(context.WithoutCancel(r.Context()), conspect, string(apperror.From(err).Code)); failErr != nil {
+		logging.FromContext(r.Context()).Errorf("Failed to record conspect failure: %v", failErr)
+	}
+	apperror.Write(w, r, err)
+}
+
+func (h *AudioHandler) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		h.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newJobID() string {
+	bytes := make([]byte, 8)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
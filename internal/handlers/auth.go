@@ -7,20 +7,20 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/goIdioms/conspect-generator/internal/apperror"
 	sessionApp "github.com/goIdioms/conspect-generator/internal/application/session"
 	userApp "github.com/goIdioms/conspect-generator/internal/application/user"
 	"github.com/goIdioms/conspect-generator/internal/constants"
 	"github.com/goIdioms/conspect-generator/internal/domain/auth"
 	"github.com/goIdioms/conspect-generator/internal/dto"
+	"github.com/goIdioms/conspect-generator/internal/logging"
 	"github.com/goIdioms/conspect-generator/internal/services"
-	"github.com/sirupsen/logrus"
 )
 
 type AuthHandler struct {
 	authService    *services.AuthService
 	userService    *userApp.Service
 	sessionService *sessionApp.Service
-	logger         *logrus.Logger
 	frontendURL    string
 }
 
@@ -28,14 +28,12 @@ func NewAuthHandler(
 	authService *services.AuthService,
 	userService *userApp.Service,
 	sessionService *sessionApp.Service,
-	logger *logrus.Logger,
 	frontendURL string,
 ) *AuthHandler {
 	return &AuthHandler{
 		authService:    authService,
 		userService:    userService,
 		sessionService: sessionService,
-		logger:         logger,
 		frontendURL:    frontendURL,
 	}
 }
@@ -63,47 +61,48 @@ func (h *AuthHandler) GoogleCallback(w http.ResponseWriter, r *http.Request) {
 	errorParam := r.URL.Query().Get("error")
 
 	if errorParam != "" {
-		h.logger.Errorf("Google OAuth error: %s", errorParam)
+		logging.FromContext(r.Context()).Errorf("Google OAuth error: %s", errorParam)
 		h.redirectToFrontendWithError(w, r, fmt.Sprintf("Google OAuth error: %s", errorParam))
 		return
 	}
 
 	if code == "" {
-		h.logger.Error("No code in callback request")
+		logging.FromContext(r.Context()).Error("No code in callback request")
 		h.redirectToFrontendWithError(w, r, "No authorization code received")
 		return
 	}
 
 	if !h.validateState(r, state) {
-		h.logger.Error("Invalid OAuth state")
+		logging.FromContext(r.Context()).Error("Invalid OAuth state")
 		h.redirectToFrontendWithError(w, r, "Invalid authorization state")
 		return
 	}
 
 	token, err := h.authService.ExchangeCode(r.Context(), code)
 	if err != nil {
-		h.logger.Errorf("Failed to exchange code: %v", err)
+		logging.FromContext(r.Context()).Errorf("Failed to exchange code: %v", err)
 		h.redirectToFrontendWithError(w, r, "Failed to exchange token")
 		return
 	}
 
 	userInfo, err := h.authService.GetUserInfo(r.Context(), token)
 	if err != nil {
-		h.logger.Errorf("Failed to get user info: %v", err)
+		logging.FromContext(r.Context()).Errorf("Failed to get user info: %v", err)
 		h.redirectToFrontendWithError(w, r, "Failed to get user info")
 		return
 	}
 
 	dbUser, err := h.userService.CreateOrUpdateUser(r.Context(), userInfo)
 	if err != nil {
-		h.logger.Errorf("Failed to save user to database: %v", err)
+		logging.FromContext(r.Context()).Errorf("Failed to save user to database: %v", err)
 		h.redirectToFrontendWithError(w, r, "Failed to save user data")
 		return
 	}
+	logging.AddField(r.Context(), logging.FieldUserID, dbUser.ID)
 
 	session, err := h.sessionService.CreateSession(r.Context(), dbUser.ID, constants.SessionDuration)
 	if err != nil {
-		h.logger.Errorf("Failed to create user session: %v", err)
+		logging.FromContext(r.Context()).Errorf("Failed to create user session: %v", err)
 		h.redirectToFrontendWithError(w, r, "Failed to create session")
 		return
 	}
@@ -116,21 +115,20 @@ func (h *AuthHandler) GoogleCallback(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	sessionCookie, err := r.Cookie(constants.CookieSessionName)
 	if err != nil {
-		http.Error(w, "Session cookie not found", http.StatusUnauthorized)
+		apperror.Write(w, r, apperror.New(apperror.CodeUnauthorized, "Session cookie not found"))
 		return
 	}
 
 	session, err := h.sessionService.ValidateSession(r.Context(), sessionCookie.Value)
 	if err != nil {
-		h.logger.Errorf("Failed to validate session: %v", err)
-		http.Error(w, "Invalid session", http.StatusUnauthorized)
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeSessionInvalid, "Invalid session", err))
 		return
 	}
+	logging.AddField(r.Context(), logging.FieldUserID, session.UserID)
 
 	user, err := h.userService.GetUserByID(r.Context(), session.UserID)
 	if err != nil {
-		h.logger.Errorf("Failed to get user: %v", err)
-		http.Error(w, "User not found", http.StatusNotFound)
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeNotFound, "User not found", err))
 		return
 	}
 
@@ -142,13 +140,12 @@ func (h *AuthHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	sessionCookie, err := r.Cookie(constants.CookieSessionName)
 	if err != nil {
-		http.Error(w, "Session cookie not found", http.StatusBadRequest)
+		apperror.Write(w, r, apperror.New(apperror.CodeInvalidRequest, "Session cookie not found"))
 		return
 	}
 
 	if err := h.sessionService.DeleteSession(r.Context(), sessionCookie.Value); err != nil {
-		h.logger.Errorf("Failed to delete session: %v", err)
-		http.Error(w, "Failed to logout", http.StatusInternalServerError)
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInternal, "Failed to logout", err))
 		return
 	}
 
@@ -160,7 +157,7 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) redirectToFrontendWithUser(w http.ResponseWriter, r *http.Request, user *services.GoogleUser, token string) {
 	userJSON, err := json.Marshal(user)
 	if err != nil {
-		h.logger.Errorf("Failed to marshal user: %v", err)
+		logging.FromContext(r.Context()).Errorf("Failed to marshal user: %v", err)
 		h.redirectToFrontendWithError(w, r, "Internal error")
 		return
 	}
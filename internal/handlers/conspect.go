@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	artifactApp "github.com/goIdioms/conspect-generator/internal/application/artifact"
+	conspectApp "github.com/goIdioms/conspect-generator/internal/application/conspect"
+	sessionApp "github.com/goIdioms/conspect-generator/internal/application/session"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	domainArtifact "github.com/goIdioms/conspect-generator/internal/domain/artifact"
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/dto"
+	"github.com/goIdioms/conspect-generator/internal/i18n"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+var transcriptFormats = []string{c.TranscriptFormatTXT, c.TranscriptFormatSRT, c.TranscriptFormatVTT, c.TranscriptFormatJSON}
+
+type ConspectHandler struct {
+	conspectService *conspectApp.Service
+	artifactService *artifactApp.Service
+}
+
+func NewConspectHandler(conspectService *conspectApp.Service, artifactService *artifactApp.Service) *ConspectHandler {
+	return &ConspectHandler{
+		conspectService: conspectService,
+		artifactService: artifactService,
+	}
+}
+
+func (h *ConspectHandler) Get(w http.ResponseWriter, r *http.Request) {
+	conspect, err := h.loadConspectAt(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewConspectResponse(conspect))
+}
+
+func (h *ConspectHandler) HTML(w http.ResponseWriter, r *http.Request) {
+	conspect, err := h.loadConspectAt(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set(c.HeaderContentType, c.ContentTypeHTML)
+	if err := renderConspectHTML(w, i18n.FromContext(r.Context()), conspect); err != nil {
+		logging.FromContext(r.Context()).Errorf("Failed to render conspect HTML: %v", err)
+	}
+}
+
+func (h *ConspectHandler) RenameSpeakers(w http.ResponseWriter, r *http.Request) {
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	var req dto.RenameSpeakersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInvalidRequest, "Request body is not valid JSON", err))
+		return
+	}
+
+	if err := h.conspectService.RenameSpeakers(r.Context(), conspect, req.Speakers); err != nil {
+		apperror.Write(w, r, conspectError(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewConspectResponse(conspect))
+}
+
+func (h *ConspectHandler) Transcript(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get(c.QueryParamFormat)
+	if format == "" {
+		format = c.TranscriptFormatTXT
+	}
+	if !isTranscriptFormat(format) {
+		apperror.Write(w, r, apperror.New(apperror.CodeInvalidParam, "Unsupported transcript format").
+			WithField(c.QueryParamFormat).
+			WithDetail("allowed", strings.Join(transcriptFormats, ", ")))
+		return
+	}
+
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	transcript, err := h.conspectService.GetTranscript(r.Context(), conspect)
+	if err != nil {
+		apperror.Write(w, r, conspectError(err))
+		return
+	}
+	*transcript = transcript.WithSpeakerNames(conspect.Speakers)
+
+	filename := fmt.Sprintf("transcript-%d.%s", conspect.ID, format)
+	switch format {
+	case c.TranscriptFormatJSON:
+		writeJSON(w, http.StatusOK, dto.NewTranscriptResponse(conspect, transcript))
+	case c.TranscriptFormatSRT:
+		writeAttachment(w, c.ContentTypeSRT, filename, transcript.SRT())
+	case c.TranscriptFormatVTT:
+		writeAttachment(w, c.ContentTypeVTT, filename, transcript.VTT())
+	default:
+		w.Header().Set(c.HeaderContentType, c.ContentTypeText)
+		w.Write([]byte(transcript.PlainText()))
+	}
+}
+
+func (h *ConspectHandler) Artifacts(w http.ResponseWriter, r *http.Request) {
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	artifacts, err := h.artifactService.List(r.Context(), conspect.ID)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	response := make([]dto.ArtifactResponse, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		url, urlExpiresAt, err := h.artifactService.Link(r.Context(), artifact)
+		if err != nil {
+			apperror.Write(w, r, err)
+			return
+		}
+		response = append(response, dto.NewArtifactResponse(artifact, url, urlExpiresAt))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ConspectHandler) DownloadArtifact(w http.ResponseWriter, r *http.Request) {
+	kind, err := domainArtifact.ParseKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeNotFound, "Unknown artifact kind", err))
+		return
+	}
+
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	artifact, err := h.artifactService.Latest(r.Context(), conspect.ID, kind)
+	if err != nil {
+		if errors.Is(err, domainArtifact.ErrArtifactNotFound) {
+			err = apperror.Wrap(apperror.CodeNotFound, "Artifact is not available", err)
+		}
+		apperror.Write(w, r, err)
+		return
+	}
+
+	url, _, err := h.artifactService.Link(r.Context(), artifact)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	w.Header().Set(c.HeaderCacheControl, c.CacheNoStore)
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+func (h *ConspectHandler) loadConspect(r *http.Request) (*domainConspect.Conspect, error) {
+	return loadConspect(r, h.conspectService)
+}
+
+func loadConspect(r *http.Request, conspectService *conspectApp.Service) (*domainConspect.Conspect, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeNotFound, "Conspect not found", err)
+	}
+
+	var userID *int
+	if sessionUserID, ok := sessionApp.UserIDFromContext(r.Context()); ok {
+		userID = &sessionUserID
+	}
+
+	jobID := r.URL.Query().Get(c.QueryParamJobID)
+	if jobID == "" {
+		jobID = r.Header.Get(c.HeaderXJobID)
+	}
+
+	conspect, err := conspectService.GetForViewer(r.Context(), id, userID, jobID)
+	if err != nil {
+		return nil, conspectError(err)
+	}
+	return conspect, nil
+}
+
+func conspectError(err error) error {
+	switch {
+	case errors.Is(err, domainConspect.ErrConspectNotFound):
+		return apperror.Wrap(apperror.CodeNotFound, "Conspect not found", err)
+	case errors.Is(err, domainConspect.ErrTranscriptNotFound):
+		return apperror.Wrap(apperror.CodeNotFound, "Transcript is not available", err)
+	case errors.Is(err, domainConspect.ErrNotCompleted):
+		return apperror.Wrap(apperror.CodeConflict, "Conspect is not completed", err)
+	case errors.Is(err, domainConspect.ErrRevisionNotFound):
+		return apperror.Wrap(apperror.CodeNotFound, "Revision not found", err)
+	case errors.Is(err, domainConspect.ErrUnknownSpeaker), errors.Is(err, domainConspect.ErrInvalidSpeakerName):
+		return apperror.Wrap(apperror.CodeInvalidParam, err.Error(), err).WithField(c.FormFieldSpeakers)
+	default:
+		return err
+	}
+}
+
+func isTranscriptFormat(format string) bool {
+	for _, allowed := range transcriptFormats {
+		if format == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func writeAttachment(w http.ResponseWriter, contentType, filename, body string) {
+	w.Header().Set(c.HeaderContentType, contentType)
+	w.Header().Set(c.HeaderContentDisposition, c.AttachmentPrefix+filename)
+	w.Write([]byte(body))
+}
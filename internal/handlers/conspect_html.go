@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/i18n"
+)
+
+var conspectHTML = template.Must(template.New("conspect").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<article>
+<h1>{{.Title}}</h1>
+{{range .Paragraphs}}<p id="p{{.Index}}"{{if .Cited}} data-start="{{.Start}}" data-end="{{.End}}"{{end}}>{{if .Cited}}<a class="timestamp" href="#t={{.Start}},{{.End}}">{{.Label}}</a> {{end}}{{.Text}}</p>
+{{end}}{{if .Glossary}}<section id="glossary">
+<h2>{{.GlossaryTitle}}</h2>
+<dl>
+{{range .Glossary}}<dt>{{.Term}}</dt><dd>{{.Definition}}</dd>
+{{end}}</dl>
+</section>
+{{end}}</article>
+</body>
+</html>
+`))
+
+type htmlParagraph struct {
+	Index int
+	Text  string
+	Cited bool
+	Label string
+	Start string
+	End   string
+}
+
+type htmlConspect struct {
+	Lang          string
+	Title         string
+	Paragraphs    []htmlParagraph
+	GlossaryTitle string
+	Glossary      []domainConspect.GlossaryEntry
+}
+
+func renderConspectHTML(w io.Writer, lang i18n.Lang, conspect *domainConspect.Conspect) error {
+	citations := make(map[int]domainConspect.Citation, len(conspect.Citations))
+	for _, citation := range conspect.Citations {
+		citations[citation.Paragraph] = citation
+	}
+
+	page := htmlConspect{
+		Lang:          string(lang),
+		Title:         i18n.T(lang, i18n.KeyPDFTitle, nil),
+		GlossaryTitle: i18n.T(lang, i18n.KeyPDFGlossary, nil),
+		Glossary:      conspect.Glossary,
+	}
+	for i, text := range domainConspect.Paragraphs(conspect.Speakers.Resolve(conspect.Summary)) {
+		paragraph := htmlParagraph{Index: i, Text: text}
+		if citation, ok := citations[i]; ok {
+			paragraph.Cited = true
+			paragraph.Label = citation.Label()
+			paragraph.Start = fmt.Sprintf("%.1f", citation.Start.Seconds())
+			paragraph.End = fmt.Sprintf("%.1f", citation.End.Seconds())
+		}
+		page.Paragraphs = append(page.Paragraphs, paragraph)
+	}
+
+	return conspectHTML.Execute(w, page)
+}
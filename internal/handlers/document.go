@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/infra/uploads"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+	"github.com/goIdioms/conspect-generator/internal/validators"
+)
+
+func (h *AudioHandler) HandleDocument(w http.ResponseWriter, r *http.Request) {
+	h.inFlight.Add(1)
+	defer h.inFlight.Done()
+
+	jobID := newJobID()
+	logging.AddField(r.Context(), logging.FieldJobID, jobID)
+	w.Header().Set(c.HeaderXJobID, jobID)
+
+	userID := optionalUserID(r)
+
+	form, err := h.receiveForm(w, r, uploads.FixedSize(validators.MaxDocumentFileSize), c.MaxTextValueSize)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	defer form.Close()
+
+	if err := h.openDocument(r.Context(), form, userID); err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	file, header := form.File, form.File.Header
+
+	format, err := validators.ValidateDocumentFile(header, file.ContentType)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	if !h.transcriptionService.SupportsDocument(format) {
+		apperror.Write(w, r, apperror.New(apperror.CodeUnsupportedDocument, "Document format is not supported on this server").
+			WithField(c.FormFieldFile).
+			WithDetail("extensions", strings.Join(h.documentExtensions(), ", ")))
+		return
+	}
+
+	opts, err := validators.ParseConversionOptions(validators.ConversionParams{
+		Pages:          form.Value(c.FormFieldPages),
+		Notes:          form.Value(c.FormFieldNotes),
+		SourceLanguage: form.Value(c.FormFieldSourceLanguage),
+		TargetLanguage: form.Value(c.FormFieldTargetLanguage),
+		Bilingual:      form.Value(c.FormFieldBilingual),
+		Style:          form.Value(c.FormFieldStyle),
+		StyleParams:    form.Value(c.FormFieldStyleParams),
+		Glossary:       form.Value(c.FormFieldGlossary),
+	})
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	st, gl, err := h.resolveOptions(r, &opts, userID)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	transcript, err := h.transcriptionService.ExtractDocument(r.Context(), file.Name(), format)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	if err := validators.ValidateDocumentText(*transcript); err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	logging.FromContext(r.Context()).Infof("Processing document: file=%s, size=%d, sha256=%s, format=%s, pages=%d", header.Filename, header.Size, file.SHA256, format, opts.Pages)
+
+	conspect, err := h.conspectService.Start(r.Context(), userID, jobID, header.Filename, domainConspect.AudioInfo{Duration: transcript.Duration}, opts)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	w.Header().Set(c.HeaderXConspectID, strconv.Itoa(conspect.ID))
+
+	result, err := h.transcriptionService.SummarizeDocument(r.Context(), *transcript, opts, st, gl)
+	if err != nil {
+		h.fail(w, r, conspect, err)
+		return
+	}
+
+	h.deliver(w, r, conspect, result, st.Layout, file)
+}
+
+func (h *AudioHandler) openDocument(ctx context.Context, form *uploads.Form, userID *int) error {
+	text := form.Value(c.FormFieldText)
+	if text == "" {
+		return h.openSource(ctx, form, userID)
+	}
+	if form.File != nil || form.Value(c.FormFieldUploadID) != "" {
+		return apperror.New(apperror.CodeInvalidParam, "Send either a file or text, not both").WithField(c.FormFieldText)
+	}
+
+	header := &multipart.FileHeader{
+		Filename: c.TextFileName,
+		Header:   textproto.MIMEHeader{},
+	}
+	header.Header.Set(c.HeaderContentType, c.ContentTypeText)
+
+	received, err := uploads.ReceiveFile(strings.NewReader(text), header, c.TempFilePattern, validators.MaxDocumentFileSize)
+	if err != nil {
+		return err
+	}
+	form.File = received
+	return nil
+}
+
+func (h *AudioHandler) documentExtensions() []string {
+	var extensions []string
+	for _, extension := range validators.DocumentExtensions {
+		if format, ok := domainConspect.DocumentFormatFromFilename(extension); ok && h.transcriptionService.SupportsDocument(format) {
+			extensions = append(extensions, extension)
+		}
+	}
+	return extensions
+}
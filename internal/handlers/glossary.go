@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	glossaryApp "github.com/goIdioms/conspect-generator/internal/application/glossary"
+	sessionApp "github.com/goIdioms/conspect-generator/internal/application/session"
+	"github.com/goIdioms/conspect-generator/internal/domain/glossary"
+	"github.com/goIdioms/conspect-generator/internal/dto"
+)
+
+type GlossaryHandler struct {
+	glossaryService *glossaryApp.Service
+}
+
+func NewGlossaryHandler(glossaryService *glossaryApp.Service) *GlossaryHandler {
+	return &GlossaryHandler{
+		glossaryService: glossaryService,
+	}
+}
+
+func (h *GlossaryHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, _ := sessionApp.UserIDFromContext(r.Context())
+
+	glossaries, err := h.glossaryService.List(r.Context(), userID)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	response := make([]*dto.GlossaryResponse, 0, len(glossaries))
+	for _, g := range glossaries {
+		response = append(response, dto.NewGlossaryResponse(g))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *GlossaryHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, _ := sessionApp.UserIDFromContext(r.Context())
+
+	id, err := glossaryID(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	g, err := h.glossaryService.Get(r.Context(), id, userID)
+	if err != nil {
+		apperror.Write(w, r, glossaryError(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewGlossaryResponse(g))
+}
+
+func (h *GlossaryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, _ := sessionApp.UserIDFromContext(r.Context())
+
+	var req dto.GlossaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInvalidRequest, "Request body is not valid JSON", err))
+		return
+	}
+
+	g, err := h.glossaryService.Create(r.Context(), userID, req.Name, req.ToTerms())
+	if err != nil {
+		apperror.Write(w, r, glossaryError(err))
+		return
+	}
+	writeJSON(w, http.StatusCreated, dto.NewGlossaryResponse(g))
+}
+
+func (h *GlossaryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, _ := sessionApp.UserIDFromContext(r.Context())
+
+	id, err := glossaryID(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	var req dto.GlossaryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInvalidRequest, "Request body is not valid JSON", err))
+		return
+	}
+
+	g, err := h.glossaryService.Update(r.Context(), id, userID, req.Name, req.ToTerms())
+	if err != nil {
+		apperror.Write(w, r, glossaryError(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewGlossaryResponse(g))
+}
+
+func (h *GlossaryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, _ := sessionApp.UserIDFromContext(r.Context())
+
+	id, err := glossaryID(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	if err := h.glossaryService.Delete(r.Context(), id, userID); err != nil {
+		apperror.Write(w, r, glossaryError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func glossaryID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, apperror.Wrap(apperror.CodeNotFound, "Glossary not found", err)
+	}
+	return id, nil
+}
+
+func glossaryError(err error) error {
+	switch {
+	case errors.Is(err, glossary.ErrGlossaryNotFound):
+		return apperror.Wrap(apperror.CodeNotFound, "Glossary not found", err)
+	case errors.Is(err, glossary.ErrGlossaryExists):
+		return apperror.Wrap(apperror.CodeConflict, "Glossary already exists", err)
+	case errors.Is(err, glossary.ErrInvalidName):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid glossary name", err).WithField("name")
+	case errors.Is(err, glossary.ErrInvalidTerms):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid glossary terms", err).
+			WithField("terms").
+			WithDetail("reason", err.Error())
+	default:
+		return err
+	}
+}
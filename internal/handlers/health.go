@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/health"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+type HealthHandler struct {
+	healthService *health.Service
+}
+
+func NewHealthHandler(healthService *health.Service) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, r, http.StatusOK, map[string]health.Status{"status": health.StatusUp})
+}
+
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := h.healthService.Check(r.Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	h.writeJSON(w, r, status, report)
+}
+
+func (h *HealthHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, body any) {
+	w.Header().Set(c.HeaderContentType, c.ContentTypeJSON)
+	w.Header().Set(c.HeaderCacheControl, c.CacheNoStore)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logging.FromContext(r.Context()).Errorf("Failed to encode health response: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/dto"
+)
+
+func (h *ConspectHandler) UpdateContent(w http.ResponseWriter, r *http.Request) {
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	var req dto.UpdateContentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInvalidRequest, "Request body is not valid JSON", err))
+		return
+	}
+
+	revision, err := h.conspectService.Edit(r.Context(), conspect, req.Content, optionalUserID(r), req.BaseRevision)
+	if err != nil {
+		apperror.Write(w, r, revisionError(err, conspect))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewRevisionContentResponse(conspect, revision))
+}
+
+func (h *ConspectHandler) Revisions(w http.ResponseWriter, r *http.Request) {
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	revisions, err := h.conspectService.Revisions(r.Context(), conspect)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	response := make([]dto.RevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		response = append(response, dto.NewRevisionResponse(conspect, revision))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *ConspectHandler) Revision(w http.ResponseWriter, r *http.Request) {
+	number, err := parseRevision(c.FormFieldRevision, chi.URLParam(r, "number"))
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	revision, err := h.conspectService.GetRevision(r.Context(), conspect, number)
+	if err != nil {
+		apperror.Write(w, r, revisionError(err, conspect))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewRevisionContentResponse(conspect, revision))
+}
+
+func (h *ConspectHandler) Rollback(w http.ResponseWriter, r *http.Request) {
+	number, err := parseRevision(c.FormFieldRevision, chi.URLParam(r, "number"))
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	var req dto.RollbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInvalidRequest, "Request body is not valid JSON", err))
+		return
+	}
+
+	revision, err := h.conspectService.Rollback(r.Context(), conspect, number, optionalUserID(r), req.BaseRevision)
+	if err != nil {
+		apperror.Write(w, r, revisionError(err, conspect))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewRevisionContentResponse(conspect, revision))
+}
+
+func (h *ConspectHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	format := query.Get(c.QueryParamFormat)
+	if format == "" {
+		format = c.DiffFormatJSON
+	}
+	if format != c.DiffFormatJSON && format != c.DiffFormatUnified {
+		apperror.Write(w, r, apperror.New(apperror.CodeInvalidParam, "Unsupported diff format").
+			WithField(c.QueryParamFormat).
+			WithDetail("allowed", c.DiffFormatJSON+", "+c.DiffFormatUnified))
+		return
+	}
+
+	from, err := parseRevision(c.QueryParamFrom, query.Get(c.QueryParamFrom))
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	to, err := parseRevision(c.QueryParamTo, query.Get(c.QueryParamTo))
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	conspect, err := h.loadConspect(r)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	if to == 0 {
+		to = conspect.Revision
+	}
+	if from == 0 {
+		from = max(to-1, 1)
+	}
+
+	lines, err := h.conspectService.Diff(r.Context(), conspect, from, to)
+	if err != nil {
+		apperror.Write(w, r, revisionError(err, conspect))
+		return
+	}
+
+	if format == c.DiffFormatUnified {
+		w.Header().Set(c.HeaderContentType, c.ContentTypeDiff)
+		w.Write([]byte(domainConspect.UnifiedDiff(lines, fmt.Sprintf("revision %d", from), fmt.Sprintf("revision %d", to), c.DiffContextLines)))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewDiffResponse(from, to, lines))
+}
+
+func (h *ConspectHandler) loadConspectAt(r *http.Request) (*domainConspect.Conspect, error) {
+	number, err := parseRevision(c.QueryParamRevision, r.URL.Query().Get(c.QueryParamRevision))
+	if err != nil {
+		return nil, err
+	}
+
+	conspect, err := h.loadConspect(r)
+	if err != nil || number == 0 {
+		return conspect, err
+	}
+
+	snapshot, err := h.conspectService.AtRevision(r.Context(), conspect, number)
+	if err != nil {
+		return nil, revisionError(err, conspect)
+	}
+	return snapshot, nil
+}
+
+func parseRevision(field, value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return 0, apperror.New(apperror.CodeInvalidParam, fmt.Sprintf("%s is not a valid revision: %q", field, value)).WithField(field)
+	}
+	return number, nil
+}
+
+func revisionError(err error, conspect *domainConspect.Conspect) error {
+	switch {
+	case errors.Is(err, domainConspect.ErrRevisionNotFound):
+		return apperror.Wrap(apperror.CodeNotFound, "Revision not found", err)
+	case errors.Is(err, domainConspect.ErrRevisionConflict):
+		return apperror.Wrap(apperror.CodeConflict, "Conspect was edited since the base revision", err).
+			WithField(c.FormFieldRevision).
+			WithDetail("revision", conspect.Revision)
+	case errors.Is(err, domainConspect.ErrInvalidContent):
+		return apperror.Wrap(apperror.CodeInvalidParam, err.Error(), err).WithField(c.FormFieldContent)
+	default:
+		return conspectError(err)
+	}
+}
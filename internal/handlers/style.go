@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	styleApp "github.com/goIdioms/conspect-generator/internal/application/style"
+	"github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
+	"github.com/goIdioms/conspect-generator/internal/dto"
+)
+
+type StyleHandler struct {
+	styleService *styleApp.Service
+}
+
+func NewStyleHandler(styleService *styleApp.Service) *StyleHandler {
+	return &StyleHandler{
+		styleService: styleService,
+	}
+}
+
+func (h *StyleHandler) List(w http.ResponseWriter, r *http.Request) {
+	styles, err := h.styleService.List(r.Context())
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	response := make([]*dto.StyleResponse, 0, len(styles))
+	for _, s := range styles {
+		response = append(response, dto.NewStyleResponse(s, false))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (h *StyleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	s, err := h.styleService.Get(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		apperror.Write(w, r, styleError(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewStyleResponse(s, true))
+}
+
+func (h *StyleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	s, err := decodeStyle(r, "")
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	if err := h.styleService.Create(r.Context(), s); err != nil {
+		apperror.Write(w, r, styleError(err))
+		return
+	}
+	writeJSON(w, http.StatusCreated, dto.NewStyleResponse(s, true))
+}
+
+func (h *StyleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	s, err := decodeStyle(r, chi.URLParam(r, "name"))
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	if err := h.styleService.Update(r.Context(), s); err != nil {
+		apperror.Write(w, r, styleError(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, dto.NewStyleResponse(s, true))
+}
+
+func (h *StyleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.styleService.Delete(r.Context(), chi.URLParam(r, "name")); err != nil {
+		apperror.Write(w, r, styleError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeStyle(r *http.Request, name string) (*style.Style, error) {
+	var req dto.StyleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, apperror.Wrap(apperror.CodeInvalidRequest, "Request body is not valid JSON", err)
+	}
+	if name != "" {
+		req.Name = name
+	}
+
+	layout, err := style.ParseLayout(req.Layout)
+	if err != nil {
+		return nil, styleError(err)
+	}
+
+	s, err := style.NewStyle(req.Name, req.Title, req.Description, layout, req.ToStyleParameters(), req.Template)
+	if err != nil {
+		return nil, styleError(err)
+	}
+	return s, nil
+}
+
+func styleError(err error) error {
+	switch {
+	case errors.Is(err, style.ErrStyleNotFound):
+		return apperror.Wrap(apperror.CodeNotFound, "Style not found", err)
+	case errors.Is(err, style.ErrStyleExists):
+		return apperror.Wrap(apperror.CodeConflict, "Style already exists", err)
+	case errors.Is(err, style.ErrStyleReadOnly):
+		return apperror.Wrap(apperror.CodeConflict, "Builtin styles are read-only", err)
+	case errors.Is(err, style.ErrInvalidName):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid style name", err).WithField("name")
+	case errors.Is(err, style.ErrInvalidLayout):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid layout", err).WithField("layout")
+	case errors.Is(err, style.ErrInvalidParameters):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid style parameters", err).WithField("parameters")
+	case errors.Is(err, style.ErrInvalidTemplate):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid prompt template", err).
+			WithField("template").
+			WithDetail("reason", err.Error())
+	case errors.Is(err, style.ErrUnknownParameter),
+		errors.Is(err, style.ErrMissingParameter),
+		errors.Is(err, style.ErrParameterTooLong):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid style parameters", err).
+			WithField(constants.FormFieldStyleParams).
+			WithDetail("reason", err.Error())
+	default:
+		return err
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
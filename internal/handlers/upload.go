@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	sessionApp "github.com/goIdioms/conspect-generator/internal/application/session"
+	uploadApp "github.com/goIdioms/conspect-generator/internal/application/upload"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/domain/upload"
+)
+
+type UploadHandler struct {
+	uploadService *uploadApp.Service
+}
+
+func NewUploadHandler(uploadService *uploadApp.Service) *UploadHandler {
+	return &UploadHandler{
+		uploadService: uploadService,
+	}
+}
+
+func (h *UploadHandler) Options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(c.HeaderTusResumable, c.TusVersion)
+	w.Header().Set(c.HeaderTusVersion, c.TusVersion)
+	w.Header().Set(c.HeaderTusExtension, c.TusExtensions)
+	w.Header().Set(c.HeaderTusMaxSize, strconv.FormatInt(h.uploadService.MaxSize(), 10))
+	w.Header().Set(c.HeaderTusChecksumAlgorithm, strings.Join(upload.ChecksumAlgorithms, ","))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UploadHandler) Create(w http.ResponseWriter, r *http.Request) {
+	length, err := strconv.ParseInt(r.Header.Get(c.HeaderUploadLength), 10, 64)
+	if err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInvalidParam, "Upload-Length is not a number", err).WithField("upload_length"))
+		return
+	}
+
+	metadata, err := parseUploadMetadata(r.Header.Get(c.HeaderUploadMetadata))
+	if err != nil {
+		apperror.Write(w, r, h.uploadError(err))
+		return
+	}
+
+	u, err := h.uploadService.Create(r.Context(), optionalUserID(r), length, metadata)
+	if err != nil {
+		apperror.Write(w, r, h.uploadError(err))
+		return
+	}
+
+	w.Header().Set(c.HeaderLocation, c.UploadsPath+"/"+u.ID)
+	w.Header().Set(c.HeaderUploadOffset, "0")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *UploadHandler) Head(w http.ResponseWriter, r *http.Request) {
+	u, err := h.uploadService.Get(r.Context(), chi.URLParam(r, "id"), optionalUserID(r))
+	if err != nil {
+		apperror.Write(w, r, h.uploadError(err))
+		return
+	}
+
+	w.Header().Set(c.HeaderCacheControl, c.CacheNoStore)
+	w.Header().Set(c.HeaderUploadOffset, strconv.FormatInt(u.Offset, 10))
+	w.Header().Set(c.HeaderUploadLength, strconv.FormatInt(u.Length, 10))
+	if len(u.Metadata) > 0 {
+		w.Header().Set(c.HeaderUploadMetadata, formatUploadMetadata(u.Metadata))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *UploadHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(c.HeaderContentType) != c.ContentTypeTusChunk {
+		apperror.Write(w, r, apperror.New(apperror.CodeUnsupportedMime, "Chunks must be sent as application/offset+octet-stream").
+			WithField("content_type").
+			WithDetail("content_type", r.Header.Get(c.HeaderContentType)))
+		return
+	}
+
+	offset, err := strconv.ParseInt(r.Header.Get(c.HeaderUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		apperror.Write(w, r, apperror.New(apperror.CodeInvalidParam, "Upload-Offset is not a valid offset").WithField("upload_offset"))
+		return
+	}
+
+	checksum, err := parseUploadChecksum(r.Header.Get(c.HeaderUploadChecksum))
+	if err != nil {
+		apperror.Write(w, r, h.uploadError(err))
+		return
+	}
+
+	u, err := h.uploadService.Write(r.Context(), chi.URLParam(r, "id"), optionalUserID(r), offset, checksum, r.Body)
+	if err != nil {
+		appErr := h.uploadError(err)
+		if errors.Is(err, upload.ErrOffsetMismatch) && u != nil {
+			appErr = apperror.From(appErr).WithDetail("offset", u.Offset)
+		}
+		apperror.Write(w, r, appErr)
+		return
+	}
+
+	w.Header().Set(c.HeaderUploadOffset, strconv.FormatInt(u.Offset, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UploadHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.uploadService.Delete(r.Context(), chi.URLParam(r, "id"), optionalUserID(r)); err != nil {
+		apperror.Write(w, r, h.uploadError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || key == "" {
+			return nil, upload.ErrInvalidMetadata
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+func formatUploadMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for key, value := range metadata {
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(value)))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func parseUploadChecksum(header string) (*upload.Checksum, error) {
+	if header == "" {
+		return nil, nil
+	}
+
+	algorithm, encoded, _ := strings.Cut(header, " ")
+	sum, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, upload.ErrUnsupportedChecksum
+	}
+	return upload.NewChecksum(algorithm, sum)
+}
+
+func optionalUserID(r *http.Request) *int {
+	if id, ok := sessionApp.UserIDFromContext(r.Context()); ok {
+		return &id
+	}
+	return nil
+}
+
+func (h *UploadHandler) uploadError(err error) error {
+	switch {
+	case errors.Is(err, upload.ErrUploadNotFound):
+		return apperror.Wrap(apperror.CodeNotFound, "Upload not found", err)
+	case errors.Is(err, upload.ErrOffsetMismatch):
+		return apperror.Wrap(apperror.CodeConflict, "Upload offset does not match", err).WithField("upload_offset")
+	case errors.Is(err, upload.ErrUploadLocked):
+		return apperror.Wrap(apperror.CodeConflict, "Upload is busy with another request", err)
+	case errors.Is(err, upload.ErrInvalidLength):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid Upload-Length", err).WithField("upload_length")
+	case errors.Is(err, upload.ErrUploadTooLarge), errors.Is(err, upload.ErrExceedsLength):
+		return apperror.Wrap(apperror.CodeFileTooLarge, "Upload is too large", err).
+			WithDetail("max_mb", h.uploadService.MaxSize()/(1024*1024))
+	case errors.Is(err, upload.ErrInvalidMetadata):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Invalid Upload-Metadata", err).WithField("upload_metadata")
+	case errors.Is(err, upload.ErrUnsupportedChecksum):
+		return apperror.Wrap(apperror.CodeInvalidParam, "Unsupported checksum algorithm", err).
+			WithField("upload_checksum").
+			WithDetail("algorithms", strings.Join(upload.ChecksumAlgorithms, ", "))
+	case errors.Is(err, upload.ErrChecksumMismatch):
+		return apperror.Wrap(apperror.CodeChecksumMismatch, "Chunk checksum does not match", err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+	"github.com/goIdioms/conspect-generator/internal/validators"
+)
+
+func (h *AudioHandler) Regenerate(w http.ResponseWriter, r *http.Request) {
+	h.inFlight.Add(1)
+	defer h.inFlight.Done()
+
+	parent, err := loadConspect(r, h.conspectService)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	if err := parseVersionForm(r); err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	defaults := parent.Options()
+	var styleParams string
+	if len(defaults.StyleParams) > 0 {
+		encoded, _ := json.Marshal(defaults.StyleParams)
+		styleParams = string(encoded)
+	}
+	var pages, glossaryID string
+	if defaults.Pages > 0 {
+		pages = strconv.Itoa(defaults.Pages)
+	}
+	if defaults.GlossaryID != 0 {
+		glossaryID = strconv.Itoa(defaults.GlossaryID)
+	}
+
+	opts, err := validators.ParseConversionOptions(validators.ConversionParams{
+		Pages:          formValueOr(r, c.FormFieldPages, pages),
+		Notes:          formValueOr(r, c.FormFieldNotes, defaults.Notes),
+		TargetLanguage: formValueOr(r, c.FormFieldTargetLanguage, defaults.TargetLanguage.Code()),
+		Bilingual:      formValueOr(r, c.FormFieldBilingual, strconv.FormatBool(defaults.Bilingual)),
+		Style:          formValueOr(r, c.FormFieldStyle, defaults.Style),
+		StyleParams:    formValueOr(r, c.FormFieldStyleParams, styleParams),
+		Glossary:       formValueOr(r, c.FormFieldGlossary, glossaryID),
+	})
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	opts.SourceLanguage = defaults.SourceLanguage
+	opts.Diarize = defaults.Diarize
+
+	userID := optionalUserID(r)
+	st, gl, err := h.resolveOptions(r, &opts, userID)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	transcript, err := h.conspectService.GetTranscript(r.Context(), parent)
+	if err != nil {
+		apperror.Write(w, r, conspectError(err))
+		return
+	}
+
+	conspect, ok := h.startVersion(w, r, parent, opts, "")
+	if !ok {
+		return
+	}
+
+	logging.FromContext(r.Context()).Infof("Regenerating conspect %d: style=%s, pages=%d", parent.ID, opts.Style, opts.Pages)
+
+	result, err := h.transcriptionService.SummarizeTranscript(r.Context(), *transcript, parent.DetectedLanguage, parent.Speakers, opts, st, gl)
+	if err != nil {
+		h.fail(w, r, conspect, err)
+		return
+	}
+
+	h.deliver(w, r, conspect, result, st.Layout, nil)
+}
+
+func (h *AudioHandler) Render(w http.ResponseWriter, r *http.Request) {
+	h.inFlight.Add(1)
+	defer h.inFlight.Done()
+
+	parent, err := loadConspect(r, h.conspectService)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	if !parent.IsCompleted() {
+		apperror.Write(w, r, conspectError(domainConspect.ErrNotCompleted))
+		return
+	}
+
+	if err := parseVersionForm(r); err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+
+	number, err := parseRevision(c.FormFieldRevision, r.Form.Get(c.FormFieldRevision))
+	if err != nil {
+		apperror.Write(w, r, err)
+		return
+	}
+	if number != 0 {
+		if parent, err = h.conspectService.AtRevision(r.Context(), parent, number); err != nil {
+			apperror.Write(w, r, conspectError(err))
+			return
+		}
+	}
+
+	value := r.Form.Get(c.FormFieldLayout)
+	if value == "" {
+		value = parent.Layout
+	}
+	if value == "" {
+		if st, err := h.styleService.Get(r.Context(), parent.Style); err == nil {
+			value = string(st.Layout)
+		}
+	}
+	layout, err := style.ParseLayout(value)
+	if err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInvalidParam, "Unknown layout", err).
+			WithField(c.FormFieldLayout).
+			WithDetail("layout", value))
+		return
+	}
+
+	result := parent.Result()
+	transcript, err := h.conspectService.GetTranscript(r.Context(), parent)
+	switch {
+	case err == nil:
+		result.Transcript = *transcript
+	case !errors.Is(err, domainConspect.ErrTranscriptNotFound):
+		apperror.Write(w, r, err)
+		return
+	}
+
+	conspect, ok := h.startVersion(w, r, parent, parent.Options(), string(layout))
+	if !ok {
+		return
+	}
+
+	logging.FromContext(r.Context()).Infof("Re-rendering conspect %d: layout=%s", parent.ID, layout)
+
+	h.deliver(w, r, conspect, &result, layout, nil)
+}
+
+func (h *AudioHandler) startVersion(w http.ResponseWriter, r *http.Request, parent *domainConspect.Conspect, opts domainConspect.Options, layout string) (*domainConspect.Conspect, bool) {
+	jobID := newJobID()
+	logging.AddField(r.Context(), logging.FieldJobID, jobID)
+	w.Header().Set(c.HeaderXJobID, jobID)
+
+	conspect, err := h.conspectService.StartVersion(r.Context(), parent, jobID, opts, layout)
+	if err != nil {
+		apperror.Write(w, r, err)
+		return nil, false
+	}
+	w.Header().Set(c.HeaderXConspectID, strconv.Itoa(conspect.ID))
+	return conspect, true
+}
+
+func parseVersionForm(r *http.Request) error {
+	if err := r.ParseMultipartForm(c.MaxFormValueSize); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return apperror.Wrap(apperror.CodeInvalidRequest, "Invalid form body", err)
+	}
+	return nil
+}
+
+func formValueOr(r *http.Request, key, fallback string) string {
+	if _, ok := r.Form[key]; ok {
+		return r.Form.Get(key)
+	}
+	return fallback
+}
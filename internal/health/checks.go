@@ -0,0 +1,24 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+func TempDirWritable(dir string) CheckFunc {
+	return func(ctx context.Context) error {
+		f, err := os.CreateTemp(dir, "healthcheck-*")
+		if err != nil {
+			return fmt.Errorf("temp dir is not writable: %w", err)
+		}
+		name := f.Name()
+		defer os.Remove(name)
+
+		if _, err := f.Write([]byte("ok")); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write temp file: %w", err)
+		}
+		return f.Close()
+	}
+}
@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Status string
+
+const (
+	StatusUp           Status = "up"
+	StatusDown         Status = "down"
+	StatusShuttingDown Status = "shutting_down"
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type Result struct {
+	Status    Status    `json:"status"`
+	LatencyMs float64   `json:"latency_ms"`
+	Cached    bool      `json:"cached"`
+	CheckedAt time.Time `json:"-"`
+}
+
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+	ttl  time.Duration
+}
+
+type Service struct {
+	checks  []check
+	timeout time.Duration
+	ready   atomic.Bool
+	mu      sync.Mutex
+	cache   map[string]Result
+	logger  *logrus.Logger
+}
+
+func NewService(timeout time.Duration, logger *logrus.Logger) *Service {
+	return &Service{
+		timeout: timeout,
+		cache:   make(map[string]Result),
+		logger:  logger,
+	}
+}
+
+func (s *Service) Register(name string, fn CheckFunc, ttl time.Duration) {
+	s.checks = append(s.checks, check{name: name, fn: fn, ttl: ttl})
+}
+
+func (s *Service) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+func (s *Service) IsReady() bool {
+	return s.ready.Load()
+}
+
+func (s *Service) Check(ctx context.Context) Report {
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(s.checks)),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, c := range s.checks {
+		wg.Add(1)
+		go func(c check) {
+			defer wg.Done()
+			result := s.run(ctx, c)
+
+			mu.Lock()
+			report.Checks[c.name] = result
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusUp {
+			report.Status = StatusDown
+			break
+		}
+	}
+	if !s.IsReady() {
+		report.Status = StatusShuttingDown
+	}
+
+	return report
+}
+
+func (s *Service) run(ctx context.Context, c check) Result {
+	s.mu.Lock()
+	cached, ok := s.cache[c.name]
+	s.mu.Unlock()
+	if ok && time.Since(cached.CheckedAt) < c.ttl {
+		cached.Cached = true
+		return cached
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.fn(checkCtx)
+	result := Result{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		CheckedAt: time.Now(),
+	}
+	if err != nil {
+		s.logger.Warnf("Health check %s failed after %s: %v", c.name, time.Since(start).Round(time.Millisecond), err)
+		result.Status = StatusDown
+	}
+
+	s.mu.Lock()
+	s.cache[c.name] = result
+	s.mu.Unlock()
+
+	return result
+}
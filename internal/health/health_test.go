@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestService() *Service {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	s := NewService(time.Second, logger)
+	s.SetReady(true)
+	return s
+}
+
+func decodeChecks(t *testing.T, report Report) map[string]map[string]any {
+	t.Helper()
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("marshal report: %v", err)
+	}
+	if strings.Contains(string(data), "password authentication failed") {
+		t.Fatalf("report exposes the check error: %s", data)
+	}
+	var decoded struct {
+		Checks map[string]map[string]any `json:"checks"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	return decoded.Checks
+}
+
+func TestCheckReportsLatencyAndCacheState(t *testing.T) {
+	s := newTestService()
+	calls := 0
+	s.Register("database", func(ctx context.Context) error {
+		calls++
+		time.Sleep(2 * time.Millisecond)
+		return errors.New("pq: password authentication failed for user \"conspect\"")
+	}, time.Minute)
+
+	first := decodeChecks(t, s.Check(context.Background()))["database"]
+	if first["status"] != string(StatusDown) {
+		t.Fatalf("status = %v, want %q", first["status"], StatusDown)
+	}
+	if latency, ok := first["latency_ms"].(float64); !ok || latency <= 0 {
+		t.Fatalf("latency_ms = %v, want a positive number", first["latency_ms"])
+	}
+	if first["cached"] != false {
+		t.Fatalf("cached = %v on the first run, want false", first["cached"])
+	}
+
+	second := decodeChecks(t, s.Check(context.Background()))["database"]
+	if second["cached"] != true {
+		t.Fatalf("cached = %v within the TTL, want true", second["cached"])
+	}
+	if second["latency_ms"] != first["latency_ms"] {
+		t.Fatalf("latency_ms = %v, want the cached %v", second["latency_ms"], first["latency_ms"])
+	}
+	if calls != 1 {
+		t.Fatalf("check ran %d times, want 1", calls)
+	}
+	if _, ok := second["error"]; ok {
+		t.Fatalf("report has an error field: %v", second)
+	}
+}
@@ -0,0 +1,193 @@
+package i18n
+
+const (
+	KeyPDFTitle  = "pdf.title"
+	KeyPDFFooter = "pdf.footer"
+
+	KeyPDFGlossary = "pdf.glossary"
+
+	KeySpeakerName = "speaker.default"
+)
+
+var catalog = map[Lang]map[string]string{
+	LangRU: {
+		"invalid_request":            "Некорректный запрос",
+		"file_missing":               "Файл не найден в запросе",
+		"file_too_large":             "Файл слишком большой. Максимум: {max_mb}MB",
+		"file_empty":                 "Файл пустой",
+		"file_unreadable":            "Не удалось прочитать файл",
+		"audio_too_long":             "Аудио слишком длинное: {duration_min} мин. Максимум: {max_min} мин",
+		"unsupported_mime":           "Неподдерживаемый тип файла: {content_type}. Разрешены только аудио и видео файлы",
+		"unsupported_extension":      "Неподдерживаемое расширение файла. Разрешены: {extensions}",
+		"video_unsupported":          "Видео не поддерживается на этом сервере, загрузите аудиодорожку",
+		"checksum_mismatch":          "Контрольная сумма фрагмента не совпадает, отправьте его повторно",
+		"unsupported_version":        "Неподдерживаемая версия протокола tus",
+		"invalid_param":              "Некорректное значение параметра {field}",
+		"invalid_param.pages":        "pages должен быть числом от {min} до {max}",
+		"invalid_param.notes":        "notes слишком длинный (максимум {max_length} символов)",
+		"invalid_param.bilingual":    "bilingual должен быть true или false",
+		"invalid_param.diarize":      "Разделение по спикерам недоступно на этом сервере",
+		"invalid_param.slides":       "Извлечение слайдов недоступно на этом сервере",
+		"invalid_param.speakers":     "Некорректные имена спикеров",
+		"invalid_param.format":       "Неподдерживаемый формат. Разрешены: {allowed}",
+		"invalid_param.style":        "Неизвестный стиль конспекта: {style}",
+		"invalid_param.style_params": "Некорректные параметры стиля: {reason}",
+		"invalid_param.template":     "Некорректный шаблон промпта: {reason}",
+		"invalid_param.glossary":     "Глоссарий не найден",
+		"invalid_param.terms":        "Некорректные термины глоссария: {reason}",
+		"unsupported_language":       "Неподдерживаемый язык: {language}",
+		"quota_exceeded":             "Слишком много запросов. Попробуйте позже.",
+		"unauthorized":               "Требуется авторизация",
+		"session_invalid":            "Сессия недействительна или истекла",
+		"not_found":                  "Не найдено",
+		"conflict":                   "Конфликт с текущим состоянием ресурса",
+		"transcription_failed":       "Не удалось распознать аудио",
+		"summarization_failed":       "Не удалось составить конспект",
+		"render_failed":              "Не удалось создать PDF",
+		"service_unavailable":        "Сервис временно недоступен",
+		"internal_error":             "Внутренняя ошибка сервера",
+		KeyPDFTitle:                  "Конспект",
+		KeyPDFFooter:                 "Страница {page} из {total}",
+		KeyPDFGlossary:               "Глоссарий",
+		KeySpeakerName:               "Спикер {n}",
+
+		"invalid_param.upload_id":       "Загрузка не найдена или ещё не завершена",
+		"invalid_param.upload_length":   "Upload-Length должен быть положительным числом",
+		"invalid_param.upload_metadata": "Upload-Metadata должен содержать имя файла (filename)",
+		"invalid_param.upload_offset":   "Upload-Offset должен быть неотрицательным числом",
+		"invalid_param.upload_checksum": "Неподдерживаемый Upload-Checksum. Разрешены: {algorithms}",
+		"conflict.upload_offset":        "Смещение загрузки не совпадает, продолжите с {offset}",
+		"unsupported_mime.content_type": "Фрагменты должны отправляться как application/offset+octet-stream",
+
+		"invalid_param.layout":   "Неизвестная вёрстка: {layout}. Разрешены: handwritten, outline, cornell",
+		"invalid_param.revision": "Номер ревизии должен быть положительным числом",
+		"invalid_param.from":     "Номер ревизии from должен быть положительным числом",
+		"invalid_param.to":       "Номер ревизии to должен быть положительным числом",
+		"invalid_param.content":  "Текст конспекта пустой или слишком длинный",
+		"conflict.revision":      "Конспект изменён после выбранной ревизии, текущая ревизия: {revision}",
+
+		"invalid_param.text":   "Передайте либо файл, либо текст",
+		"unsupported_document": "Неподдерживаемый документ. Разрешены: {extensions}",
+		"document_too_long":    "Документ слишком длинный: {chars} символов. Максимум: {max_chars}",
+	},
+	LangEN: {
+		"invalid_request":            "Invalid request",
+		"file_missing":               "File is missing from the request",
+		"file_too_large":             "File is too large. Maximum: {max_mb}MB",
+		"file_empty":                 "File is empty",
+		"file_unreadable":            "Failed to read the file",
+		"audio_too_long":             "Audio is too long: {duration_min} min. Maximum: {max_min} min",
+		"unsupported_mime":           "Unsupported file type: {content_type}. Only audio and video files are allowed",
+		"unsupported_extension":      "Unsupported file extension. Allowed: {extensions}",
+		"video_unsupported":          "Video uploads are not supported on this server, upload the audio track instead",
+		"checksum_mismatch":          "Chunk checksum does not match, send it again",
+		"unsupported_version":        "Unsupported tus protocol version",
+		"invalid_param":              "Invalid value for parameter {field}",
+		"invalid_param.pages":        "pages must be a number from {min} to {max}",
+		"invalid_param.notes":        "notes is too long (maximum {max_length} characters)",
+		"invalid_param.bilingual":    "bilingual must be true or false",
+		"invalid_param.diarize":      "Speaker diarization is not available on this server",
+		"invalid_param.slides":       "Slide extraction is not available on this server",
+		"invalid_param.speakers":     "Invalid speaker names",
+		"invalid_param.format":       "Unsupported format. Allowed: {allowed}",
+		"invalid_param.style":        "Unknown conspect style: {style}",
+		"invalid_param.style_params": "Invalid style parameters: {reason}",
+		"invalid_param.template":     "Invalid prompt template: {reason}",
+		"invalid_param.glossary":     "Glossary not found",
+		"invalid_param.terms":        "Invalid glossary terms: {reason}",
+		"unsupported_language":       "Unsupported language: {language}",
+		"quota_exceeded":             "Too many requests. Please try again later.",
+		"unauthorized":               "Authentication required",
+		"session_invalid":            "Session is invalid or expired",
+		"not_found":                  "Not found",
+		"conflict":                   "Conflicts with the current state of the resource",
+		"transcription_failed":       "Failed to transcribe the audio",
+		"summarization_failed":       "Failed to generate the conspect",
+		"render_failed":              "Failed to render the PDF",
+		"service_unavailable":        "Service is temporarily unavailable",
+		"internal_error":             "Internal server error",
+		KeyPDFTitle:                  "Notes",
+		KeyPDFFooter:                 "Page {page} of {total}",
+		KeyPDFGlossary:               "Glossary",
+		KeySpeakerName:               "Speaker {n}",
+
+		"invalid_param.upload_id":       "Upload not found or not finished yet",
+		"invalid_param.upload_length":   "Upload-Length must be a positive number",
+		"invalid_param.upload_metadata": "Upload-Metadata must include a filename",
+		"invalid_param.upload_offset":   "Upload-Offset must be a non-negative number",
+		"invalid_param.upload_checksum": "Unsupported Upload-Checksum. Allowed: {algorithms}",
+		"conflict.upload_offset":        "Upload offset does not match, resume from {offset}",
+		"unsupported_mime.content_type": "Chunks must be sent as application/offset+octet-stream",
+
+		"invalid_param.layout":   "Unknown layout: {layout}. Allowed: handwritten, outline, cornell",
+		"invalid_param.revision": "Revision must be a positive number",
+		"invalid_param.from":     "Revision from must be a positive number",
+		"invalid_param.to":       "Revision to must be a positive number",
+		"invalid_param.content":  "Conspect content is empty or too long",
+		"conflict.revision":      "The conspect was edited after the base revision, the current revision is {revision}",
+
+		"invalid_param.text":   "Send either a file or text, not both",
+		"unsupported_document": "Unsupported document. Allowed: {extensions}",
+		"document_too_long":    "Document is too long: {chars} characters. Maximum: {max_chars}",
+	},
+	LangUK: {
+		"invalid_request":            "Некоректний запит",
+		"file_missing":               "Файл не знайдено в запиті",
+		"file_too_large":             "Файл занадто великий. Максимум: {max_mb}MB",
+		"file_empty":                 "Файл порожній",
+		"file_unreadable":            "Не вдалося прочитати файл",
+		"audio_too_long":             "Аудіо занадто довге: {duration_min} хв. Максимум: {max_min} хв",
+		"unsupported_mime":           "Непідтримуваний тип файлу: {content_type}. Дозволені лише аудіо- та відеофайли",
+		"unsupported_extension":      "Непідтримуване розширення файлу. Дозволені: {extensions}",
+		"video_unsupported":          "Відео не підтримується на цьому сервері, завантажте аудіодоріжку",
+		"checksum_mismatch":          "Контрольна сума фрагмента не збігається, надішліть його повторно",
+		"unsupported_version":        "Непідтримувана версія протоколу tus",
+		"invalid_param":              "Некоректне значення параметра {field}",
+		"invalid_param.pages":        "pages має бути числом від {min} до {max}",
+		"invalid_param.notes":        "notes занадто довгий (максимум {max_length} символів)",
+		"invalid_param.bilingual":    "bilingual має бути true або false",
+		"invalid_param.diarize":      "Розділення за спікерами недоступне на цьому сервері",
+		"invalid_param.slides":       "Витягування слайдів недоступне на цьому сервері",
+		"invalid_param.speakers":     "Некоректні імена спікерів",
+		"invalid_param.format":       "Непідтримуваний формат. Дозволені: {allowed}",
+		"invalid_param.style":        "Невідомий стиль конспекту: {style}",
+		"invalid_param.style_params": "Некоректні параметри стилю: {reason}",
+		"invalid_param.template":     "Некоректний шаблон промпту: {reason}",
+		"invalid_param.glossary":     "Глосарій не знайдено",
+		"invalid_param.terms":        "Некоректні терміни глосарію: {reason}",
+		"unsupported_language":       "Непідтримувана мова: {language}",
+		"quota_exceeded":             "Забагато запитів. Спробуйте пізніше.",
+		"unauthorized":               "Потрібна авторизація",
+		"session_invalid":            "Сесія недійсна або закінчилася",
+		"not_found":                  "Не знайдено",
+		"conflict":                   "Конфлікт із поточним станом ресурсу",
+		"transcription_failed":       "Не вдалося розпізнати аудіо",
+		"summarization_failed":       "Не вдалося скласти конспект",
+		"render_failed":              "Не вдалося створити PDF",
+		"service_unavailable":        "Сервіс тимчасово недоступний",
+		"internal_error":             "Внутрішня помилка сервера",
+		KeyPDFTitle:                  "Конспект",
+		KeyPDFFooter:                 "Сторінка {page} з {total}",
+		KeyPDFGlossary:               "Глосарій",
+		KeySpeakerName:               "Спікер {n}",
+
+		"invalid_param.upload_id":       "Завантаження не знайдено або ще не завершено",
+		"invalid_param.upload_length":   "Upload-Length має бути додатним числом",
+		"invalid_param.upload_metadata": "Upload-Metadata має містити ім'я файлу (filename)",
+		"invalid_param.upload_offset":   "Upload-Offset має бути невід'ємним числом",
+		"invalid_param.upload_checksum": "Непідтримуваний Upload-Checksum. Дозволені: {algorithms}",
+		"conflict.upload_offset":        "Зміщення завантаження не збігається, продовжте з {offset}",
+		"unsupported_mime.content_type": "Фрагменти мають надсилатися як application/offset+octet-stream",
+
+		"invalid_param.layout":   "Невідома верстка: {layout}. Дозволені: handwritten, outline, cornell",
+		"invalid_param.revision": "Номер ревізії має бути додатним числом",
+		"invalid_param.from":     "Номер ревізії from має бути додатним числом",
+		"invalid_param.to":       "Номер ревізії to має бути додатним числом",
+		"invalid_param.content":  "Текст конспекту порожній або занадто довгий",
+		"conflict.revision":      "Конспект змінено після вибраної ревізії, поточна ревізія: {revision}",
+
+		"invalid_param.text":   "Передайте або файл, або текст",
+		"unsupported_document": "Непідтримуваний документ. Дозволені: {extensions}",
+		"document_too_long":    "Документ занадто довгий: {chars} символів. Максимум: {max_chars}",
+	},
+}
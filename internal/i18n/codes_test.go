@@ -0,0 +1,18 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	"github.com/goIdioms/conspect-generator/internal/i18n"
+)
+
+func TestEveryErrorCodeIsTranslated(t *testing.T) {
+	for _, code := range apperror.Codes() {
+		for _, lang := range i18n.Supported {
+			if !i18n.Has(lang, string(code)) {
+				t.Errorf("error code %s has no %s translation", code, lang)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Lang string
+
+const (
+	LangRU Lang = "ru"
+	LangEN Lang = "en"
+	LangUK Lang = "uk"
+
+	DefaultLang  = LangRU
+	FallbackLang = LangEN
+)
+
+var Supported = []Lang{LangRU, LangEN, LangUK}
+
+type contextKey struct{}
+
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(contextKey{}).(Lang); ok {
+		return lang
+	}
+	return DefaultLang
+}
+
+func Parse(value string) (Lang, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, "-_"); i > 0 {
+		value = value[:i]
+	}
+	for _, lang := range Supported {
+		if Lang(value) == lang {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+func Negotiate(acceptLanguage, preference string) Lang {
+	if lang, ok := Parse(preference); ok {
+		return lang
+	}
+
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return DefaultLang
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].lang
+}
+
+func T(lang Lang, key string, params map[string]any) string {
+	message, ok := lookup(lang, key)
+	if !ok {
+		return key
+	}
+	return interpolate(message, params)
+}
+
+func Has(lang Lang, key string) bool {
+	_, ok := catalog[lang][key]
+	return ok
+}
+
+func Missing() []string {
+	keys := make(map[string]bool)
+	for _, messages := range catalog {
+		for key := range messages {
+			keys[key] = true
+		}
+	}
+
+	var missing []string
+	for key := range keys {
+		for _, lang := range Supported {
+			if !Has(lang, key) {
+				missing = append(missing, fmt.Sprintf("%s:%s", lang, key))
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+func lookup(lang Lang, key string) (string, bool) {
+	for _, l := range []Lang{lang, FallbackLang, DefaultLang} {
+		if message, ok := catalog[l][key]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+func interpolate(message string, params map[string]any) string {
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", fmt.Sprint(value))
+	}
+	return message
+}
@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{[a-z_]+\}`)
+
+func TestCatalogHasNoMissingTranslations(t *testing.T) {
+	if missing := Missing(); len(missing) > 0 {
+		t.Fatalf("missing translations: %v", missing)
+	}
+}
+
+func TestMissingReportsKeysAbsentFromAnyLocale(t *testing.T) {
+	catalog[LangEN]["test.only_en"] = "only english"
+	defer delete(catalog[LangEN], "test.only_en")
+
+	missing := Missing()
+	for _, want := range []string{"ru:test.only_en", "uk:test.only_en"} {
+		if !slices.Contains(missing, want) {
+			t.Errorf("Missing() = %v, want it to contain %s", missing, want)
+		}
+	}
+}
+
+func TestTranslationsUseTheSamePlaceholders(t *testing.T) {
+	for key, message := range catalog[DefaultLang] {
+		want := placeholders(message)
+		for _, lang := range Supported {
+			translated, ok := catalog[lang][key]
+			if !ok {
+				continue
+			}
+			if got := placeholders(translated); !slices.Equal(got, want) {
+				t.Errorf("%s:%s uses placeholders %v, want %v", lang, key, got, want)
+			}
+		}
+	}
+}
+
+func placeholders(message string) []string {
+	found := placeholderPattern.FindAllString(message, -1)
+	slices.Sort(found)
+	return slices.Compact(found)
+}
@@ -0,0 +1,186 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	outputPattern    = "normalized-*.mp3"
+	sampleRate       = "16000"
+	bitrate          = "64k"
+	loudnessFilter   = "loudnorm=I=-16:TP=-1.5:LRA=11"
+	silenceFilter    = "silencedetect=noise=-50dB:duration=1"
+	silenceTolerance = 100 * time.Millisecond
+	maxErrorOutput   = 512
+)
+
+var (
+	tracer = tracing.Tracer("github.com/goIdioms/conspect-generator/internal/infra/audio")
+
+	silenceStartPattern = regexp.MustCompile(`silence_start: (-?[0-9.]+)`)
+	silenceEndPattern   = regexp.MustCompile(`silence_end: ([0-9.]+)`)
+)
+
+type FFmpegPreprocessor struct {
+	ffmpegPath  string
+	ffprobePath string
+	trimSilence bool
+	speed       float64
+	timeout     time.Duration
+}
+
+func NewFFmpegPreprocessor(ffmpegPath, ffprobePath string, trimSilence bool, speed float64, timeout time.Duration) *FFmpegPreprocessor {
+	return &FFmpegPreprocessor{
+		ffmpegPath:  ffmpegPath,
+		ffprobePath: ffprobePath,
+		trimSilence: trimSilence,
+		speed:       speed,
+		timeout:     timeout,
+	}
+}
+
+func (p *FFmpegPreprocessor) Prepare(ctx context.Context, filePath string) (*domainConspect.PreparedAudio, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	source, err := probe(ctx, p.ffprobePath, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var start, end time.Duration
+	if p.trimSilence && source.Duration > 0 {
+		if start, end, err = p.detectSilence(ctx, filePath, source.Duration); err != nil {
+			return nil, err
+		}
+	}
+
+	output, err := os.CreateTemp("", outputPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	output.Close()
+
+	if err := p.transcode(ctx, filePath, output.Name(), start, end); err != nil {
+		os.Remove(output.Name())
+		return nil, err
+	}
+
+	return &domainConspect.PreparedAudio{
+		Path:   output.Name(),
+		Source: source,
+		Speed:  p.speed,
+		Offset: start,
+	}, nil
+}
+
+func (p *FFmpegPreprocessor) transcode(ctx context.Context, input, output string, start, end time.Duration) error {
+	ctx, span := tracer.Start(ctx, "ffmpeg.transcode")
+	defer span.End()
+
+	args := []string{"-hide_banner", "-nostdin", "-y"}
+	if start > 0 {
+		args = append(args, "-ss", seconds(start))
+	}
+	if end > 0 {
+		args = append(args, "-to", seconds(end))
+	}
+
+	filters := []string{loudnessFilter}
+	if p.speed != 1 {
+		filters = append(filters, "atempo="+strconv.FormatFloat(p.speed, 'f', 2, 64))
+	}
+
+	args = append(args,
+		"-i", input,
+		"-map", "0:a:0",
+		"-vn",
+		"-af", strings.Join(filters, ","),
+		"-ac", "1",
+		"-ar", sampleRate,
+		"-c:a", "libmp3lame",
+		"-b:a", bitrate,
+		output,
+	)
+	span.SetAttributes(
+		attribute.Float64("audio.speed", p.speed),
+		attribute.Float64("audio.trim_start_seconds", start.Seconds()),
+	)
+
+	if _, err := p.run(ctx, args...); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+func (p *FFmpegPreprocessor) detectSilence(ctx context.Context, input string, duration time.Duration) (time.Duration, time.Duration, error) {
+	ctx, span := tracer.Start(ctx, "ffmpeg.silencedetect")
+	defer span.End()
+
+	output, err := p.run(ctx, "-hide_banner", "-nostdin", "-i", input, "-map", "0:a:0", "-af", silenceFilter, "-f", "null", "-")
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, 0, err
+	}
+
+	starts := silenceStartPattern.FindAllStringSubmatch(output, -1)
+	ends := silenceEndPattern.FindAllStringSubmatch(output, -1)
+	if len(starts) == 0 {
+		return 0, 0, nil
+	}
+
+	var start, end time.Duration
+	if parseSeconds(starts[0][1]) <= silenceTolerance && len(ends) > 0 {
+		start = parseSeconds(ends[0][1])
+	}
+
+	last := parseSeconds(starts[len(starts)-1][1])
+	if len(ends) < len(starts) || duration-parseSeconds(ends[len(ends)-1][1]) <= silenceTolerance {
+		if last > start {
+			end = last
+		}
+	}
+	return start, end, nil
+}
+
+func (p *FFmpegPreprocessor) run(ctx context.Context, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.ffmpegPath, args...)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("ffmpeg was interrupted: %w", ctx.Err())
+		}
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("%w: %s", domainConspect.ErrUndecodableAudio, tail(stderr.String()))
+		}
+		return "", fmt.Errorf("failed to run ffmpeg: %w", err)
+	}
+	return stderr.String(), nil
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+func parseSeconds(value string) time.Duration {
+	s, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,23 @@
+package audio
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+type PassthroughPreprocessor struct{}
+
+func NewPassthroughPreprocessor() *PassthroughPreprocessor {
+	return &PassthroughPreprocessor{}
+}
+
+func (p *PassthroughPreprocessor) Prepare(_ context.Context, filePath string) (*domainConspect.PreparedAudio, error) {
+	return &domainConspect.PreparedAudio{
+		Path:   filePath,
+		Source: domainConspect.AudioInfo{Container: strings.TrimPrefix(filepath.Ext(filePath), ".")},
+		Speed:  1,
+	}, nil
+}
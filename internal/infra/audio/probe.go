@@ -0,0 +1,80 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+type probeOutput struct {
+	Format struct {
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+	} `json:"format"`
+	Streams []struct {
+		CodecType   string `json:"codec_type"`
+		CodecName   string `json:"codec_name"`
+		Disposition struct {
+			AttachedPic int `json:"attached_pic"`
+		} `json:"disposition"`
+	} `json:"streams"`
+}
+
+func probe(ctx context.Context, ffprobePath, filePath string) (domainConspect.AudioInfo, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ffprobePath,
+		"-v", "error",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		filePath,
+	)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return domainConspect.AudioInfo{}, fmt.Errorf("%w: %s", domainConspect.ErrUndecodableAudio, tail(stderr.String()))
+		}
+		return domainConspect.AudioInfo{}, fmt.Errorf("failed to run ffprobe: %w", err)
+	}
+
+	var output probeOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return domainConspect.AudioInfo{}, fmt.Errorf("failed to decode ffprobe output: %w", err)
+	}
+
+	info := domainConspect.AudioInfo{
+		Container: strings.Split(output.Format.FormatName, ",")[0],
+	}
+	if seconds, err := strconv.ParseFloat(output.Format.Duration, 64); err == nil {
+		info.Duration = time.Duration(seconds * float64(time.Second))
+	}
+	for _, stream := range output.Streams {
+		switch {
+		case stream.CodecType == "video" && stream.Disposition.AttachedPic == 0:
+			info.Video = true
+		case stream.CodecType == "audio" && info.Codec == "":
+			info.Codec = stream.CodecName
+		}
+	}
+	if info.Codec == "" {
+		return info, domainConspect.ErrNoAudioStream
+	}
+	return info, nil
+}
+
+func tail(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxErrorOutput {
+		output = "..." + output[len(output)-maxErrorOutput:]
+	}
+	return output
+}
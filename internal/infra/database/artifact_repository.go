@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	domainArtifact "github.com/goIdioms/conspect-generator/internal/domain/artifact"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+)
+
+const artifactColumns = `id, conspect_id, kind, object_key, filename, content_type, size, created_at, expires_at`
+
+type ArtifactRepository struct {
+	db *sql.DB
+}
+
+func NewArtifactRepository(db *sql.DB) *ArtifactRepository {
+	return &ArtifactRepository{db: db}
+}
+
+func (r *ArtifactRepository) FindByConspect(ctx context.Context, conspectID int) ([]*domainArtifact.Artifact, error) {
+	query := `SELECT ` + artifactColumns + ` FROM artifacts WHERE conspect_id = $1 ORDER BY created_at DESC, id DESC`
+	ctx, span := startSpan(ctx, "ArtifactRepository.FindByConspect", query)
+	defer span.End()
+
+	artifacts, err := r.query(ctx, query, conspectID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find artifacts by conspect: %w", err)
+	}
+	return artifacts, nil
+}
+
+func (r *ArtifactRepository) FindExpired(ctx context.Context, before time.Time) ([]*domainArtifact.Artifact, error) {
+	query := `SELECT ` + artifactColumns + ` FROM artifacts WHERE expires_at < $1`
+	ctx, span := startSpan(ctx, "ArtifactRepository.FindExpired", query)
+	defer span.End()
+
+	artifacts, err := r.query(ctx, query, before)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find expired artifacts: %w", err)
+	}
+	return artifacts, nil
+}
+
+func (r *ArtifactRepository) Create(ctx context.Context, artifact *domainArtifact.Artifact) error {
+	query := `
+		INSERT INTO artifacts (conspect_id, kind, object_key, filename, content_type, size, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+
+	ctx, span := startSpan(ctx, "ArtifactRepository.Create", query)
+	defer span.End()
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		artifact.ConspectID,
+		artifact.Kind,
+		artifact.Key,
+		artifact.Filename,
+		artifact.ContentType,
+		artifact.Size,
+		artifact.ExpiresAt,
+	).Scan(&artifact.ID, &artifact.CreatedAt)
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to create artifact: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ArtifactRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM artifacts WHERE id = $1`
+	ctx, span := startSpan(ctx, "ArtifactRepository.Delete", query)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return domainArtifact.ErrArtifactNotFound
+	}
+
+	return nil
+}
+
+func (r *ArtifactRepository) query(ctx context.Context, query string, args ...any) ([]*domainArtifact.Artifact, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var artifacts []*domainArtifact.Artifact
+	for rows.Next() {
+		artifact, err := scanArtifact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan artifact: %w", err)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, rows.Err()
+}
+
+func scanArtifact(row rowScanner) (*domainArtifact.Artifact, error) {
+	var (
+		artifact  domainArtifact.Artifact
+		expiresAt sql.NullTime
+	)
+
+	err := row.Scan(
+		&artifact.ID,
+		&artifact.ConspectID,
+		&artifact.Kind,
+		&artifact.Key,
+		&artifact.Filename,
+		&artifact.ContentType,
+		&artifact.Size,
+		&artifact.CreatedAt,
+		&expiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		artifact.ExpiresAt = &expiresAt.Time
+	}
+
+	return &artifact, nil
+}
@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+)
+
+type storedSegment struct {
+	Position int    `json:"position"`
+	StartMs  int64  `json:"start_ms"`
+	EndMs    int64  `json:"end_ms"`
+	Speaker  string `json:"speaker,omitempty"`
+	Text     string `json:"text"`
+}
+
+type storedTranscript struct {
+	Text             string            `json:"text"`
+	DurationMs       int64             `json:"duration_ms"`
+	Segments         []storedSegment   `json:"segments"`
+	DetectedLanguage string            `json:"detected_language,omitempty"`
+	Speakers         map[string]string `json:"speakers,omitempty"`
+	Video            bool              `json:"video"`
+}
+
+type storedSummary struct {
+	Summary       string          `json:"summary"`
+	SourceSummary string          `json:"source_summary,omitempty"`
+	ActualPages   int             `json:"actual_pages"`
+	Citations     json.RawMessage `json:"citations"`
+	Glossary      json.RawMessage `json:"glossary"`
+}
+
+type CacheRepository struct {
+	db *sql.DB
+}
+
+func NewCacheRepository(db *sql.DB) *CacheRepository {
+	return &CacheRepository{db: db}
+}
+
+func (r *CacheRepository) FindTranscript(ctx context.Context, key string) (*domainConspect.CachedTranscript, error) {
+	payload, err := r.find(ctx, "CacheRepository.FindTranscript", `UPDATE transcript_cache SET used_at = CURRENT_TIMESTAMP WHERE cache_key = $1 RETURNING payload`, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored storedTranscript
+	if err := json.Unmarshal(payload, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode cached transcript: %w", err)
+	}
+
+	cached := &domainConspect.CachedTranscript{
+		Transcript: domainConspect.Transcript{
+			Text:     stored.Text,
+			Duration: time.Duration(stored.DurationMs) * time.Millisecond,
+			Segments: make([]domainConspect.Segment, 0, len(stored.Segments)),
+		},
+		Speakers: stored.Speakers,
+		Video:    stored.Video,
+	}
+	cached.DetectedLanguage, _ = domainConspect.NewLanguage(stored.DetectedLanguage)
+	for _, s := range stored.Segments {
+		cached.Transcript.Segments = append(cached.Transcript.Segments, domainConspect.Segment{
+			Position: s.Position,
+			Start:    time.Duration(s.StartMs) * time.Millisecond,
+			End:      time.Duration(s.EndMs) * time.Millisecond,
+			Speaker:  s.Speaker,
+			Text:     s.Text,
+		})
+	}
+	return cached, nil
+}
+
+func (r *CacheRepository) SaveTranscript(ctx context.Context, key string, transcript *domainConspect.CachedTranscript) error {
+	stored := storedTranscript{
+		Text:             transcript.Transcript.Text,
+		DurationMs:       transcript.Transcript.Duration.Milliseconds(),
+		Segments:         make([]storedSegment, 0, len(transcript.Transcript.Segments)),
+		DetectedLanguage: transcript.DetectedLanguage.Code(),
+		Speakers:         transcript.Speakers,
+		Video:            transcript.Video,
+	}
+	for _, s := range transcript.Transcript.Segments {
+		stored.Segments = append(stored.Segments, storedSegment{
+			Position: s.Position,
+			StartMs:  s.Start.Milliseconds(),
+			EndMs:    s.End.Milliseconds(),
+			Speaker:  s.Speaker,
+			Text:     s.Text,
+		})
+	}
+
+	payload, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode cached transcript: %w", err)
+	}
+	return r.save(ctx, "CacheRepository.SaveTranscript", `
+		INSERT INTO transcript_cache (cache_key, payload) VALUES ($1, $2)
+		ON CONFLICT (cache_key) DO UPDATE SET payload = EXCLUDED.payload, used_at = CURRENT_TIMESTAMP
+	`, key, payload)
+}
+
+func (r *CacheRepository) FindSummary(ctx context.Context, key string) (*domainConspect.CachedSummary, error) {
+	payload, err := r.find(ctx, "CacheRepository.FindSummary", `UPDATE summary_cache SET used_at = CURRENT_TIMESTAMP WHERE cache_key = $1 RETURNING payload`, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored storedSummary
+	if err := json.Unmarshal(payload, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode cached summary: %w", err)
+	}
+
+	cached := &domainConspect.CachedSummary{
+		Summary:       stored.Summary,
+		SourceSummary: stored.SourceSummary,
+		ActualPages:   stored.ActualPages,
+	}
+	if cached.Citations, err = unmarshalCitations(stored.Citations); err != nil {
+		return nil, err
+	}
+	if cached.Glossary, err = unmarshalGlossary(stored.Glossary); err != nil {
+		return nil, err
+	}
+	return cached, nil
+}
+
+func (r *CacheRepository) SaveSummary(ctx context.Context, key string, summary *domainConspect.CachedSummary) error {
+	citations, err := marshalCitations(summary.Citations)
+	if err != nil {
+		return err
+	}
+	glossary, err := marshalGlossary(summary.Glossary)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(storedSummary{
+		Summary:       summary.Summary,
+		SourceSummary: summary.SourceSummary,
+		ActualPages:   summary.ActualPages,
+		Citations:     citations,
+		Glossary:      glossary,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode cached summary: %w", err)
+	}
+	return r.save(ctx, "CacheRepository.SaveSummary", `
+		INSERT INTO summary_cache (cache_key, payload) VALUES ($1, $2)
+		ON CONFLICT (cache_key) DO UPDATE SET payload = EXCLUDED.payload, used_at = CURRENT_TIMESTAMP
+	`, key, payload)
+}
+
+func (r *CacheRepository) DeleteUnusedBefore(ctx context.Context, before time.Time) (int64, error) {
+	transcripts, err := r.deleteUnused(ctx, "CacheRepository.DeleteUnusedTranscripts", `DELETE FROM transcript_cache WHERE used_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	summaries, err := r.deleteUnused(ctx, "CacheRepository.DeleteUnusedSummaries", `DELETE FROM summary_cache WHERE used_at < $1`, before)
+	if err != nil {
+		return transcripts, err
+	}
+	return transcripts + summaries, nil
+}
+
+func (r *CacheRepository) find(ctx context.Context, spanName, query, key string) ([]byte, error) {
+	ctx, span := startSpan(ctx, spanName, query)
+	defer span.End()
+
+	var payload []byte
+	err := r.db.QueryRowContext(ctx, query, key).Scan(&payload)
+	if err == sql.ErrNoRows {
+		return nil, domainConspect.ErrCacheMiss
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+	return payload, nil
+}
+
+func (r *CacheRepository) save(ctx context.Context, spanName, query, key string, payload []byte) error {
+	ctx, span := startSpan(ctx, spanName, query)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, query, key, payload); err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+func (r *CacheRepository) deleteUnused(ctx context.Context, spanName, query string, before time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, spanName, query)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, fmt.Errorf("failed to delete unused cache entries: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return rows, nil
+}
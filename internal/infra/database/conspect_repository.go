@@ -0,0 +1,493 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+)
+
+const conspectColumns = `
+	id, user_id, parent_id, job_id, status, source_filename, source_language, target_language,
+	detected_language, bilingual, style, style_params, layout, pages, actual_pages, notes,
+	summary, citations, source_summary, revision, error_code, diarize, speakers,
+	glossary_id, glossary, audio_container, audio_codec, audio_duration_ms,
+	audio_sample_rate, audio_channels, audio_video, created_at, updated_at, completed_at
+`
+
+type ConspectRepository struct {
+	db *sql.DB
+}
+
+func NewConspectRepository(db *sql.DB) *ConspectRepository {
+	return &ConspectRepository{db: db}
+}
+
+func (r *ConspectRepository) FindByID(ctx context.Context, id int) (*domainConspect.Conspect, error) {
+	query := `SELECT ` + conspectColumns + ` FROM conspects WHERE id = $1`
+	ctx, span := startSpan(ctx, "ConspectRepository.FindByID", query)
+	defer span.End()
+
+	conspect, err := scanConspect(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, domainConspect.ErrConspectNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find conspect by ID: %w", err)
+	}
+
+	return conspect, nil
+}
+
+func (r *ConspectRepository) FindByJobID(ctx context.Context, jobID string) (*domainConspect.Conspect, error) {
+	query := `SELECT ` + conspectColumns + ` FROM conspects WHERE job_id = $1`
+	ctx, span := startSpan(ctx, "ConspectRepository.FindByJobID", query)
+	defer span.End()
+
+	conspect, err := scanConspect(r.db.QueryRowContext(ctx, query, jobID))
+	if err == sql.ErrNoRows {
+		return nil, domainConspect.ErrConspectNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find conspect by job ID: %w", err)
+	}
+
+	return conspect, nil
+}
+
+func (r *ConspectRepository) Create(ctx context.Context, conspect *domainConspect.Conspect) error {
+	query := `
+		INSERT INTO conspects (
+			user_id, job_id, status, source_filename, source_language, target_language,
+			bilingual, style, style_params, pages, notes, diarize, glossary_id,
+			audio_container, audio_codec, audio_duration_ms, audio_sample_rate, audio_channels,
+			audio_video, parent_id, layout
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+		RETURNING id, created_at, updated_at
+	`
+
+	ctx, span := startSpan(ctx, "ConspectRepository.Create", query)
+	defer span.End()
+
+	styleParams, err := json.Marshal(conspect.StyleParams)
+	if err != nil {
+		return fmt.Errorf("failed to encode style params: %w", err)
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		conspect.UserID,
+		conspect.JobID,
+		conspect.Status,
+		conspect.SourceFilename,
+		nullableLanguage(conspect.SourceLanguage),
+		nullableLanguage(conspect.TargetLanguage),
+		conspect.Bilingual,
+		conspect.Style,
+		styleParams,
+		conspect.Pages,
+		conspect.Notes,
+		conspect.Diarize,
+		conspect.GlossaryID,
+		sql.NullString{String: conspect.Audio.Container, Valid: conspect.Audio.Container != ""},
+		sql.NullString{String: conspect.Audio.Codec, Valid: conspect.Audio.Codec != ""},
+		sql.NullInt64{Int64: conspect.Audio.Duration.Milliseconds(), Valid: conspect.Audio.Duration > 0},
+		sql.NullInt64{Int64: int64(conspect.Audio.SampleRate), Valid: conspect.Audio.SampleRate > 0},
+		sql.NullInt64{Int64: int64(conspect.Audio.Channels), Valid: conspect.Audio.Channels > 0},
+		conspect.Audio.Video,
+		conspect.ParentID,
+		sql.NullString{String: conspect.Layout, Valid: conspect.Layout != ""},
+	).Scan(&conspect.ID, &conspect.CreatedAt, &conspect.UpdatedAt)
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to create conspect: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ConspectRepository) Update(ctx context.Context, conspect *domainConspect.Conspect) error {
+	query := `
+		UPDATE conspects
+		SET status = $1, target_language = $2, detected_language = $3, summary = $4,
+		    source_summary = $5, error_code = $6, completed_at = $7, actual_pages = $8,
+		    citations = $9, speakers = $10, glossary = $11, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $12
+		RETURNING updated_at
+	`
+
+	ctx, span := startSpan(ctx, "ConspectRepository.Update", query)
+	defer span.End()
+
+	citations, err := marshalCitations(conspect.Citations)
+	if err != nil {
+		return err
+	}
+
+	speakers, err := json.Marshal(conspect.Speakers)
+	if err != nil {
+		return fmt.Errorf("failed to encode speakers: %w", err)
+	}
+
+	glossary, err := marshalGlossary(conspect.Glossary)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		conspect.Status,
+		nullableLanguage(conspect.TargetLanguage),
+		nullableLanguage(conspect.DetectedLanguage),
+		conspect.Summary,
+		conspect.SourceSummary,
+		conspect.ErrorCode,
+		conspect.CompletedAt,
+		conspect.ActualPages,
+		citations,
+		speakers,
+		glossary,
+		conspect.ID,
+	).Scan(&conspect.UpdatedAt)
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to update conspect: %w", err)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanConspect(row rowScanner) (*domainConspect.Conspect, error) {
+	var (
+		conspect                                 domainConspect.Conspect
+		userID, parentID, glossaryID             sql.NullInt64
+		sourceLang, targetLang, detectedLang     sql.NullString
+		notes, summary, sourceSummary, errorCode sql.NullString
+		style, layout                            sql.NullString
+		styleParams, citations, speakers         []byte
+		glossary                                 []byte
+		audioContainer, audioCodec               sql.NullString
+		audioDurationMs, sampleRate, channels    sql.NullInt64
+		pages, actualPages                       sql.NullInt64
+		completedAt                              sql.NullTime
+		status                                   string
+		video                                    bool
+	)
+
+	err := row.Scan(
+		&conspect.ID,
+		&userID,
+		&parentID,
+		&conspect.JobID,
+		&status,
+		&conspect.SourceFilename,
+		&sourceLang,
+		&targetLang,
+		&detectedLang,
+		&conspect.Bilingual,
+		&style,
+		&styleParams,
+		&layout,
+		&pages,
+		&actualPages,
+		&notes,
+		&summary,
+		&citations,
+		&sourceSummary,
+		&conspect.Revision,
+		&errorCode,
+		&conspect.Diarize,
+		&speakers,
+		&glossaryID,
+		&glossary,
+		&audioContainer,
+		&audioCodec,
+		&audioDurationMs,
+		&sampleRate,
+		&channels,
+		&video,
+		&conspect.CreatedAt,
+		&conspect.UpdatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		id := int(userID.Int64)
+		conspect.UserID = &id
+	}
+	if parentID.Valid {
+		id := int(parentID.Int64)
+		conspect.ParentID = &id
+	}
+	if glossaryID.Valid {
+		id := int(glossaryID.Int64)
+		conspect.GlossaryID = &id
+	}
+	if completedAt.Valid {
+		conspect.CompletedAt = &completedAt.Time
+	}
+
+	conspect.Status = domainConspect.Status(status)
+	conspect.SourceLanguage, _ = domainConspect.NewLanguage(sourceLang.String)
+	conspect.TargetLanguage, _ = domainConspect.NewLanguage(targetLang.String)
+	conspect.DetectedLanguage, _ = domainConspect.NewLanguage(detectedLang.String)
+	conspect.Style = style.String
+	conspect.Layout = layout.String
+	if len(styleParams) > 0 {
+		if err := json.Unmarshal(styleParams, &conspect.StyleParams); err != nil {
+			return nil, fmt.Errorf("failed to decode style params: %w", err)
+		}
+	}
+	if conspect.Citations, err = unmarshalCitations(citations); err != nil {
+		return nil, err
+	}
+	if len(speakers) > 0 {
+		if err := json.Unmarshal(speakers, &conspect.Speakers); err != nil {
+			return nil, fmt.Errorf("failed to decode speakers: %w", err)
+		}
+	}
+	if conspect.Glossary, err = unmarshalGlossary(glossary); err != nil {
+		return nil, err
+	}
+	conspect.Audio = domainConspect.AudioInfo{
+		Container:  audioContainer.String,
+		Codec:      audioCodec.String,
+		Duration:   time.Duration(audioDurationMs.Int64) * time.Millisecond,
+		SampleRate: int(sampleRate.Int64),
+		Channels:   int(channels.Int64),
+		Video:      video,
+	}
+	conspect.Pages = int(pages.Int64)
+	conspect.ActualPages = int(actualPages.Int64)
+	conspect.Notes = notes.String
+	conspect.Summary = summary.String
+	conspect.SourceSummary = sourceSummary.String
+	conspect.ErrorCode = errorCode.String
+
+	return &conspect, nil
+}
+
+type storedCitation struct {
+	Paragraph    int   `json:"paragraph"`
+	FirstSegment int   `json:"first_segment"`
+	LastSegment  int   `json:"last_segment"`
+	StartMs      int64 `json:"start_ms"`
+	EndMs        int64 `json:"end_ms"`
+}
+
+func marshalCitations(citations []domainConspect.Citation) ([]byte, error) {
+	stored := make([]storedCitation, 0, len(citations))
+	for _, c := range citations {
+		stored = append(stored, storedCitation{
+			Paragraph:    c.Paragraph,
+			FirstSegment: c.FirstSegment,
+			LastSegment:  c.LastSegment,
+			StartMs:      c.Start.Milliseconds(),
+			EndMs:        c.End.Milliseconds(),
+		})
+	}
+
+	encoded, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode citations: %w", err)
+	}
+	return encoded, nil
+}
+
+func unmarshalCitations(encoded []byte) ([]domainConspect.Citation, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+
+	var stored []storedCitation
+	if err := json.Unmarshal(encoded, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode citations: %w", err)
+	}
+
+	citations := make([]domainConspect.Citation, 0, len(stored))
+	for _, c := range stored {
+		citations = append(citations, domainConspect.Citation{
+			Paragraph:    c.Paragraph,
+			FirstSegment: c.FirstSegment,
+			LastSegment:  c.LastSegment,
+			Start:        time.Duration(c.StartMs) * time.Millisecond,
+			End:          time.Duration(c.EndMs) * time.Millisecond,
+		})
+	}
+	return citations, nil
+}
+
+type storedGlossaryEntry struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition"`
+}
+
+func marshalGlossary(entries []domainConspect.GlossaryEntry) ([]byte, error) {
+	stored := make([]storedGlossaryEntry, 0, len(entries))
+	for _, e := range entries {
+		stored = append(stored, storedGlossaryEntry(e))
+	}
+
+	encoded, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode glossary: %w", err)
+	}
+	return encoded, nil
+}
+
+func unmarshalGlossary(encoded []byte) ([]domainConspect.GlossaryEntry, error) {
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+
+	var stored []storedGlossaryEntry
+	if err := json.Unmarshal(encoded, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode glossary: %w", err)
+	}
+
+	entries := make([]domainConspect.GlossaryEntry, 0, len(stored))
+	for _, e := range stored {
+		entries = append(entries, domainConspect.GlossaryEntry(e))
+	}
+	return entries, nil
+}
+
+func nullableLanguage(language domainConspect.Language) sql.NullString {
+	return sql.NullString{String: language.Code(), Valid: !language.IsZero()}
+}
+
+func (r *ConspectRepository) SaveTranscript(ctx context.Context, conspectID int, transcript domainConspect.Transcript) error {
+	query := `INSERT INTO transcript_segments (conspect_id, position, start_ms, end_ms, speaker, text) VALUES ($1, $2, $3, $4, $5, $6)`
+	ctx, span := startSpan(ctx, "ConspectRepository.SaveTranscript", query)
+	defer span.End()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE conspects SET transcript = $1, audio_duration_ms = $2 WHERE id = $3`,
+		transcript.Text, transcript.Duration.Milliseconds(), conspectID,
+	)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to save transcript text: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM transcript_segments WHERE conspect_id = $1`, conspectID); err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to clear transcript segments: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to prepare segment insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, segment := range transcript.Segments {
+		_, err := stmt.ExecContext(ctx,
+			conspectID,
+			segment.Position,
+			segment.Start.Milliseconds(),
+			segment.End.Milliseconds(),
+			sql.NullString{String: segment.Speaker, Valid: segment.Speaker != ""},
+			segment.Text,
+		)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return fmt.Errorf("failed to save transcript segment: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to commit transcript: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ConspectRepository) FindTranscript(ctx context.Context, conspectID int) (*domainConspect.Transcript, error) {
+	query := `
+		SELECT position, start_ms, end_ms, speaker, text
+		FROM transcript_segments
+		WHERE conspect_id = $1
+		ORDER BY position
+	`
+	ctx, span := startSpan(ctx, "ConspectRepository.FindTranscript", query)
+	defer span.End()
+
+	var (
+		transcript domainConspect.Transcript
+		text       sql.NullString
+		durationMs sql.NullInt64
+	)
+	err := r.db.QueryRowContext(ctx,
+		`SELECT transcript, audio_duration_ms FROM conspects WHERE id = $1`, conspectID,
+	).Scan(&text, &durationMs)
+	if err == sql.ErrNoRows {
+		return nil, domainConspect.ErrConspectNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find transcript: %w", err)
+	}
+	if !text.Valid {
+		return nil, domainConspect.ErrTranscriptNotFound
+	}
+	transcript.Text = text.String
+	transcript.Duration = time.Duration(durationMs.Int64) * time.Millisecond
+
+	rows, err := r.db.QueryContext(ctx, query, conspectID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find transcript segments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			segment        domainConspect.Segment
+			startMs, endMs int64
+			speaker        sql.NullString
+		)
+		if err := rows.Scan(&segment.Position, &startMs, &endMs, &speaker, &segment.Text); err != nil {
+			tracing.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan transcript segment: %w", err)
+		}
+		segment.Start = time.Duration(startMs) * time.Millisecond
+		segment.End = time.Duration(endMs) * time.Millisecond
+		segment.Speaker = speaker.String
+		transcript.Segments = append(transcript.Segments, segment)
+	}
+
+	if err := rows.Err(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to iterate transcript segments: %w", err)
+	}
+
+	return &transcript, nil
+}
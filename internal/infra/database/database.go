@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -50,6 +51,21 @@ func (d *Database) runMigrations() error {
 	return migrator.Up()
 }
 
+func (d *Database) Ping(ctx context.Context) error {
+	return d.db.PingContext(ctx)
+}
+
+func (d *Database) CheckMigrations(ctx context.Context) error {
+	pending, err := NewMigrator(d.db, d.logger).Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending migration(s), first: %d", len(pending), pending[0].Version)
+	}
+	return nil
+}
+
 func (d *Database) Close() error {
 	return d.db.Close()
 }
@@ -0,0 +1,208 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	domainGlossary "github.com/goIdioms/conspect-generator/internal/domain/glossary"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+	"github.com/lib/pq"
+)
+
+const (
+	glossaryColumns     = `id, user_id, name, terms, created_at, updated_at`
+	uniqueViolationCode = "23505"
+)
+
+type GlossaryRepository struct {
+	db *sql.DB
+}
+
+type storedTerm struct {
+	Term       string   `json:"term"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Definition string   `json:"definition,omitempty"`
+}
+
+func NewGlossaryRepository(db *sql.DB) *GlossaryRepository {
+	return &GlossaryRepository{db: db}
+}
+
+func (r *GlossaryRepository) FindByUserID(ctx context.Context, userID int) ([]*domainGlossary.Glossary, error) {
+	query := `SELECT ` + glossaryColumns + ` FROM glossaries WHERE user_id = $1 ORDER BY name`
+	ctx, span := startSpan(ctx, "GlossaryRepository.FindByUserID", query)
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find glossaries: %w", err)
+	}
+	defer rows.Close()
+
+	var glossaries []*domainGlossary.Glossary
+	for rows.Next() {
+		glossary, err := scanGlossary(rows)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan glossary: %w", err)
+		}
+		glossaries = append(glossaries, glossary)
+	}
+
+	if err := rows.Err(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to iterate glossaries: %w", err)
+	}
+
+	return glossaries, nil
+}
+
+func (r *GlossaryRepository) FindByID(ctx context.Context, id int) (*domainGlossary.Glossary, error) {
+	query := `SELECT ` + glossaryColumns + ` FROM glossaries WHERE id = $1`
+	ctx, span := startSpan(ctx, "GlossaryRepository.FindByID", query)
+	defer span.End()
+
+	glossary, err := scanGlossary(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, domainGlossary.ErrGlossaryNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find glossary by ID: %w", err)
+	}
+
+	return glossary, nil
+}
+
+func (r *GlossaryRepository) Create(ctx context.Context, glossary *domainGlossary.Glossary) error {
+	query := `
+		INSERT INTO glossaries (user_id, name, terms)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, name) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+
+	ctx, span := startSpan(ctx, "GlossaryRepository.Create", query)
+	defer span.End()
+
+	terms, err := marshalTerms(glossary.Terms)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, query, glossary.UserID, glossary.Name, terms).
+		Scan(&glossary.ID, &glossary.CreatedAt, &glossary.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domainGlossary.ErrGlossaryExists
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to create glossary: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GlossaryRepository) Update(ctx context.Context, glossary *domainGlossary.Glossary) error {
+	query := `
+		UPDATE glossaries
+		SET name = $1, terms = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	ctx, span := startSpan(ctx, "GlossaryRepository.Update", query)
+	defer span.End()
+
+	terms, err := marshalTerms(glossary.Terms)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, query, glossary.Name, terms, glossary.ID).Scan(&glossary.UpdatedAt)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return domainGlossary.ErrGlossaryExists
+	}
+	if err == sql.ErrNoRows {
+		return domainGlossary.ErrGlossaryNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to update glossary: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GlossaryRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM glossaries WHERE id = $1`
+	ctx, span := startSpan(ctx, "GlossaryRepository.Delete", query)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to delete glossary: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return domainGlossary.ErrGlossaryNotFound
+	}
+
+	return nil
+}
+
+func scanGlossary(row rowScanner) (*domainGlossary.Glossary, error) {
+	var (
+		glossary domainGlossary.Glossary
+		terms    []byte
+	)
+
+	err := row.Scan(
+		&glossary.ID,
+		&glossary.UserID,
+		&glossary.Name,
+		&terms,
+		&glossary.CreatedAt,
+		&glossary.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []storedTerm
+	if err := json.Unmarshal(terms, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode glossary terms: %w", err)
+	}
+	for _, t := range stored {
+		glossary.Terms = append(glossary.Terms, domainGlossary.Term(t))
+	}
+
+	return &glossary, nil
+}
+
+func marshalTerms(terms []domainGlossary.Term) ([]byte, error) {
+	stored := make([]storedTerm, 0, len(terms))
+	for _, t := range terms {
+		stored = append(stored, storedTerm(t))
+	}
+
+	encoded, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode glossary terms: %w", err)
+	}
+	return encoded, nil
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_conspects_job_id;
+DROP INDEX IF EXISTS idx_conspects_user_id;
+DROP TABLE IF EXISTS conspects;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS conspects (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    job_id VARCHAR(64) UNIQUE NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    source_filename VARCHAR(255) NOT NULL,
+    source_language VARCHAR(8),
+    target_language VARCHAR(8),
+    detected_language VARCHAR(8),
+    bilingual BOOLEAN DEFAULT FALSE,
+    pages INTEGER,
+    notes TEXT,
+    summary TEXT,
+    source_summary TEXT,
+    error_code VARCHAR(64),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_conspects_user_id ON conspects(user_id);
+CREATE INDEX IF NOT EXISTS idx_conspects_job_id ON conspects(job_id);
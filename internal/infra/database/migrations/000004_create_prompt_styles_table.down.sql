@@ -0,0 +1,4 @@
+ALTER TABLE conspects DROP COLUMN IF EXISTS style_params;
+ALTER TABLE conspects DROP COLUMN IF EXISTS style;
+
+DROP TABLE IF EXISTS prompt_styles;
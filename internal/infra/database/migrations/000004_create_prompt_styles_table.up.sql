@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS prompt_styles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) UNIQUE NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    layout VARCHAR(32) NOT NULL,
+    parameters JSONB NOT NULL DEFAULT '[]',
+    template TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS style VARCHAR(64);
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS style_params JSONB;
@@ -0,0 +1 @@
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS actual_pages INTEGER;
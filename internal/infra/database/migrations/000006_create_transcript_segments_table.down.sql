@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS transcript_segments;
+
+ALTER TABLE conspects DROP COLUMN IF EXISTS audio_duration_ms;
+ALTER TABLE conspects DROP COLUMN IF EXISTS transcript;
@@ -0,0 +1,14 @@
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS transcript TEXT;
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS audio_duration_ms BIGINT;
+
+CREATE TABLE IF NOT EXISTS transcript_segments (
+    id SERIAL PRIMARY KEY,
+    conspect_id INTEGER NOT NULL REFERENCES conspects(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    start_ms BIGINT NOT NULL,
+    end_ms BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    UNIQUE (conspect_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transcript_segments_text ON transcript_segments USING GIN (to_tsvector('simple', text));
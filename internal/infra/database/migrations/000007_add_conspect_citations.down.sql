@@ -0,0 +1 @@
+ALTER TABLE conspects DROP COLUMN IF EXISTS citations;
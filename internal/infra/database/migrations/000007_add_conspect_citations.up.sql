@@ -0,0 +1 @@
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS citations JSONB;
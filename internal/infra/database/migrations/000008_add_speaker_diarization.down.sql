@@ -0,0 +1,4 @@
+ALTER TABLE transcript_segments DROP COLUMN IF EXISTS speaker;
+
+ALTER TABLE conspects DROP COLUMN IF EXISTS speakers;
+ALTER TABLE conspects DROP COLUMN IF EXISTS diarize;
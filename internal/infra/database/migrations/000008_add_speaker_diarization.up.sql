@@ -0,0 +1,4 @@
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS diarize BOOLEAN DEFAULT FALSE;
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS speakers JSONB;
+
+ALTER TABLE transcript_segments ADD COLUMN IF NOT EXISTS speaker VARCHAR(64);
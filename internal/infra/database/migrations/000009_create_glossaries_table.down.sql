@@ -0,0 +1,4 @@
+ALTER TABLE conspects DROP COLUMN IF EXISTS glossary;
+ALTER TABLE conspects DROP COLUMN IF EXISTS glossary_id;
+
+DROP TABLE IF EXISTS glossaries;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS glossaries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    terms JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_glossaries_user_id ON glossaries(user_id);
+
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS glossary_id INTEGER REFERENCES glossaries(id) ON DELETE SET NULL;
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS glossary JSONB;
@@ -0,0 +1,4 @@
+ALTER TABLE conspects DROP COLUMN IF EXISTS audio_channels;
+ALTER TABLE conspects DROP COLUMN IF EXISTS audio_sample_rate;
+ALTER TABLE conspects DROP COLUMN IF EXISTS audio_codec;
+ALTER TABLE conspects DROP COLUMN IF EXISTS audio_container;
@@ -0,0 +1,4 @@
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS audio_container VARCHAR(32);
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS audio_codec VARCHAR(32);
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS audio_sample_rate INTEGER;
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS audio_channels SMALLINT;
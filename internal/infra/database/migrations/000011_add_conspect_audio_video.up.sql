@@ -0,0 +1 @@
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS audio_video BOOLEAN NOT NULL DEFAULT FALSE;
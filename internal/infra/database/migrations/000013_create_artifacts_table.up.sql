@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS artifacts (
+    id SERIAL PRIMARY KEY,
+    conspect_id INTEGER NOT NULL REFERENCES conspects(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    object_key VARCHAR(512) NOT NULL UNIQUE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_artifacts_conspect_id ON artifacts(conspect_id);
+CREATE INDEX IF NOT EXISTS idx_artifacts_expires_at ON artifacts(expires_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS summary_cache;
+DROP TABLE IF EXISTS transcript_cache;
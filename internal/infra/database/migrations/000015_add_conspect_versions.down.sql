@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_conspects_parent_id;
+
+ALTER TABLE conspects DROP COLUMN IF EXISTS layout;
+ALTER TABLE conspects DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,4 @@
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES conspects(id) ON DELETE SET NULL;
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS layout VARCHAR(32);
+
+CREATE INDEX IF NOT EXISTS idx_conspects_parent_id ON conspects(parent_id);
@@ -0,0 +1,3 @@
+ALTER TABLE conspects DROP COLUMN IF EXISTS revision;
+
+DROP TABLE IF EXISTS conspect_revisions;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS conspect_revisions (
+    id SERIAL PRIMARY KEY,
+    conspect_id INTEGER NOT NULL REFERENCES conspects(id) ON DELETE CASCADE,
+    number INTEGER NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    content TEXT NOT NULL,
+    citations JSONB,
+    author_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    restored_from INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (conspect_id, number)
+);
+
+ALTER TABLE conspects ADD COLUMN IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 0;
+
+INSERT INTO conspect_revisions (conspect_id, number, kind, content, citations, created_at)
+SELECT id, 1, 'initial', summary, citations, COALESCE(completed_at, updated_at)
+FROM conspects
+WHERE status = 'completed' AND summary IS NOT NULL
+ON CONFLICT (conspect_id, number) DO NOTHING;
+
+UPDATE conspects SET revision = 1 WHERE status = 'completed' AND summary IS NOT NULL AND revision = 0;
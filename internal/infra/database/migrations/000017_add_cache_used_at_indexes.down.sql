@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_summary_cache_used_at;
+DROP INDEX IF EXISTS idx_transcript_cache_used_at;
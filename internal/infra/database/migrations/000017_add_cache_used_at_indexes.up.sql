@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_transcript_cache_used_at ON transcript_cache(used_at);
+CREATE INDEX IF NOT EXISTS idx_summary_cache_used_at ON summary_cache(used_at);
@@ -48,6 +48,14 @@ func (m *Migrator) createMigrationsTable() error {
 	return nil
 }
 
+func (m *Migrator) migrationsTableExists() (bool, error) {
+	var exists bool
+	if err := m.db.QueryRow("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+	return exists, nil
+}
+
 func (m *Migrator) getAppliedVersions() (map[int]bool, error) {
 	applied := make(map[int]bool)
 
@@ -245,6 +253,34 @@ func (m *Migrator) Down(steps int) error {
 	return nil
 }
 
+func (m *Migrator) Pending() ([]Migration, error) {
+	exists, err := m.migrationsTableExists()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]bool)
+	if exists {
+		if applied, err = m.getAppliedVersions(); err != nil {
+			return nil, err
+		}
+	}
+
+	migrations, err := m.loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
 func (m *Migrator) Status() error {
 	if err := m.createMigrationsTable(); err != nil {
 		return err
@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+	"github.com/lib/pq"
+)
+
+const revisionColumns = `id, conspect_id, number, kind, content, citations, author_id, restored_from, created_at`
+
+type RevisionRepository struct {
+	db *sql.DB
+}
+
+func NewRevisionRepository(db *sql.DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+func (r *RevisionRepository) FindByConspect(ctx context.Context, conspectID int) ([]*domainConspect.Revision, error) {
+	query := `SELECT ` + revisionColumns + ` FROM conspect_revisions WHERE conspect_id = $1 ORDER BY number DESC`
+	ctx, span := startSpan(ctx, "RevisionRepository.FindByConspect", query)
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, query, conspectID)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domainConspect.Revision
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (r *RevisionRepository) FindByNumber(ctx context.Context, conspectID, number int) (*domainConspect.Revision, error) {
+	query := `SELECT ` + revisionColumns + ` FROM conspect_revisions WHERE conspect_id = $1 AND number = $2`
+	ctx, span := startSpan(ctx, "RevisionRepository.FindByNumber", query)
+	defer span.End()
+
+	revision, err := scanRevision(r.db.QueryRowContext(ctx, query, conspectID, number))
+	if err == sql.ErrNoRows {
+		return nil, domainConspect.ErrRevisionNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+func (r *RevisionRepository) Create(ctx context.Context, conspect *domainConspect.Conspect, revision *domainConspect.Revision) error {
+	query := `
+		INSERT INTO conspect_revisions (conspect_id, number, kind, content, citations, author_id, restored_from, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	ctx, span := startSpan(ctx, "RevisionRepository.Create", query)
+	defer span.End()
+
+	citations, err := marshalCitations(revision.Citations)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		revision.ConspectID,
+		revision.Number,
+		revision.Kind,
+		revision.Content,
+		citations,
+		revision.AuthorID,
+		revision.RestoredFrom,
+		revision.CreatedAt,
+	).Scan(&revision.ID)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return domainConspect.ErrRevisionConflict
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to create revision: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE conspects SET summary = $1, citations = $2, revision = $3, updated_at = $4 WHERE id = $5`,
+		revision.Content, citations, revision.Number, revision.CreatedAt, revision.ConspectID,
+	)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to update conspect head: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to commit revision: %w", err)
+	}
+
+	conspect.ApplyRevision(revision)
+	return nil
+}
+
+func scanRevision(row rowScanner) (*domainConspect.Revision, error) {
+	var (
+		revision               domainConspect.Revision
+		citations              []byte
+		authorID, restoredFrom sql.NullInt64
+		kind                   string
+	)
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.ConspectID,
+		&revision.Number,
+		&kind,
+		&revision.Content,
+		&citations,
+		&authorID,
+		&restoredFrom,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	revision.Kind = domainConspect.RevisionKind(kind)
+	if authorID.Valid {
+		id := int(authorID.Int64)
+		revision.AuthorID = &id
+	}
+	if restoredFrom.Valid {
+		number := int(restoredFrom.Int64)
+		revision.RestoredFrom = &number
+	}
+	if revision.Citations, err = unmarshalCitations(citations); err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
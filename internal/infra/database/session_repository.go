@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	domainSession "github.com/goIdioms/conspect-generator/internal/domain/session"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
 )
 
 type SessionRepository struct {
@@ -23,6 +24,9 @@ func (r *SessionRepository) FindByToken(ctx context.Context, token domainSession
 		WHERE token = $1
 	`
 
+	ctx, span := startSpan(ctx, "SessionRepository.FindByToken", query)
+	defer span.End()
+
 	var session domainSession.Session
 	var tokenStr string
 
@@ -39,6 +43,7 @@ func (r *SessionRepository) FindByToken(ctx context.Context, token domainSession
 		return nil, domainSession.ErrSessionNotFound
 	}
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, fmt.Errorf("failed to find session: %w", err)
 	}
 
@@ -54,8 +59,12 @@ func (r *SessionRepository) FindByUserID(ctx context.Context, userID int) ([]*do
 		ORDER BY created_at DESC
 	`
 
+	ctx, span := startSpan(ctx, "SessionRepository.FindByUserID", query)
+	defer span.End()
+
 	rows, err := r.db.QueryContext(ctx, query, userID)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, fmt.Errorf("failed to find sessions: %w", err)
 	}
 	defer rows.Close()
@@ -74,6 +83,7 @@ func (r *SessionRepository) FindByUserID(ctx context.Context, userID int) ([]*do
 			&session.UpdatedAt,
 		)
 		if err != nil {
+			tracing.RecordError(span, err)
 			return nil, fmt.Errorf("failed to scan session: %w", err)
 		}
 
@@ -91,6 +101,9 @@ func (r *SessionRepository) Create(ctx context.Context, session *domainSession.S
 		RETURNING id, created_at, updated_at
 	`
 
+	ctx, span := startSpan(ctx, "SessionRepository.Create", query)
+	defer span.End()
+
 	err := r.db.QueryRowContext(
 		ctx,
 		query,
@@ -100,6 +113,7 @@ func (r *SessionRepository) Create(ctx context.Context, session *domainSession.S
 	).Scan(&session.ID, &session.CreatedAt, &session.UpdatedAt)
 
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
@@ -114,6 +128,9 @@ func (r *SessionRepository) Update(ctx context.Context, session *domainSession.S
 		RETURNING updated_at
 	`
 
+	ctx, span := startSpan(ctx, "SessionRepository.Update", query)
+	defer span.End()
+
 	err := r.db.QueryRowContext(
 		ctx,
 		query,
@@ -122,6 +139,7 @@ func (r *SessionRepository) Update(ctx context.Context, session *domainSession.S
 	).Scan(&session.UpdatedAt)
 
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to update session: %w", err)
 	}
 
@@ -130,13 +148,18 @@ func (r *SessionRepository) Update(ctx context.Context, session *domainSession.S
 
 func (r *SessionRepository) Delete(ctx context.Context, token domainSession.Token) error {
 	query := `DELETE FROM user_sessions WHERE token = $1`
+	ctx, span := startSpan(ctx, "SessionRepository.Delete", query)
+	defer span.End()
+
 	result, err := r.db.ExecContext(ctx, query, token.String())
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
@@ -149,22 +172,45 @@ func (r *SessionRepository) Delete(ctx context.Context, token domainSession.Toke
 
 func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID int) error {
 	query := `DELETE FROM user_sessions WHERE user_id = $1`
+	ctx, span := startSpan(ctx, "SessionRepository.DeleteByUserID", query)
+	defer span.End()
+
 	_, err := r.db.ExecContext(ctx, query, userID)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to delete sessions: %w", err)
 	}
 	return nil
 }
 
+func (r *SessionRepository) CountActive(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM user_sessions WHERE expires_at > CURRENT_TIMESTAMP`
+	ctx, span := startSpan(ctx, "SessionRepository.CountActive", query)
+	defer span.End()
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		tracing.RecordError(span, err)
+		return 0, fmt.Errorf("failed to count active sessions: %w", err)
+	}
+
+	return count, nil
+}
+
 func (r *SessionRepository) CleanExpired(ctx context.Context) (int64, error) {
 	query := `DELETE FROM user_sessions WHERE expires_at < CURRENT_TIMESTAMP`
+	ctx, span := startSpan(ctx, "SessionRepository.CleanExpired", query)
+	defer span.End()
+
 	result, err := r.db.ExecContext(ctx, query)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return 0, fmt.Errorf("failed to clean expired sessions: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
+		tracing.RecordError(span, err)
 		return 0, fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
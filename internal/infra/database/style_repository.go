@@ -0,0 +1,226 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	domainStyle "github.com/goIdioms/conspect-generator/internal/domain/style"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+)
+
+const styleColumns = `id, name, title, description, layout, parameters, template, created_at, updated_at`
+
+type StyleRepository struct {
+	db *sql.DB
+}
+
+type styleParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+func NewStyleRepository(db *sql.DB) *StyleRepository {
+	return &StyleRepository{db: db}
+}
+
+func (r *StyleRepository) FindAll(ctx context.Context) ([]*domainStyle.Style, error) {
+	query := `SELECT ` + styleColumns + ` FROM prompt_styles ORDER BY name`
+	ctx, span := startSpan(ctx, "StyleRepository.FindAll", query)
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find styles: %w", err)
+	}
+	defer rows.Close()
+
+	var styles []*domainStyle.Style
+	for rows.Next() {
+		style, err := scanStyle(rows)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan style: %w", err)
+		}
+		styles = append(styles, style)
+	}
+
+	if err := rows.Err(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to iterate styles: %w", err)
+	}
+
+	return styles, nil
+}
+
+func (r *StyleRepository) FindByName(ctx context.Context, name string) (*domainStyle.Style, error) {
+	query := `SELECT ` + styleColumns + ` FROM prompt_styles WHERE name = $1`
+	ctx, span := startSpan(ctx, "StyleRepository.FindByName", query)
+	defer span.End()
+
+	style, err := scanStyle(r.db.QueryRowContext(ctx, query, name))
+	if err == sql.ErrNoRows {
+		return nil, domainStyle.ErrStyleNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find style by name: %w", err)
+	}
+
+	return style, nil
+}
+
+func (r *StyleRepository) Create(ctx context.Context, style *domainStyle.Style) error {
+	query := `
+		INSERT INTO prompt_styles (name, title, description, layout, parameters, template)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+
+	ctx, span := startSpan(ctx, "StyleRepository.Create", query)
+	defer span.End()
+
+	params, err := marshalStyleParameters(style.Parameters)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		style.Name,
+		style.Title,
+		style.Description,
+		style.Layout,
+		params,
+		style.Template,
+	).Scan(&style.ID, &style.CreatedAt, &style.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domainStyle.ErrStyleExists
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to create style: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StyleRepository) Update(ctx context.Context, style *domainStyle.Style) error {
+	query := `
+		UPDATE prompt_styles
+		SET title = $1, description = $2, layout = $3, parameters = $4, template = $5,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE name = $6
+		RETURNING id, created_at, updated_at
+	`
+
+	ctx, span := startSpan(ctx, "StyleRepository.Update", query)
+	defer span.End()
+
+	params, err := marshalStyleParameters(style.Parameters)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		style.Title,
+		style.Description,
+		style.Layout,
+		params,
+		style.Template,
+		style.Name,
+	).Scan(&style.ID, &style.CreatedAt, &style.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domainStyle.ErrStyleNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to update style: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StyleRepository) Delete(ctx context.Context, name string) error {
+	query := `DELETE FROM prompt_styles WHERE name = $1`
+	ctx, span := startSpan(ctx, "StyleRepository.Delete", query)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, query, name)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to delete style: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return domainStyle.ErrStyleNotFound
+	}
+
+	return nil
+}
+
+func scanStyle(row rowScanner) (*domainStyle.Style, error) {
+	var (
+		style       domainStyle.Style
+		description sql.NullString
+		layout      string
+		params      []byte
+	)
+
+	err := row.Scan(
+		&style.ID,
+		&style.Name,
+		&style.Title,
+		&description,
+		&layout,
+		&params,
+		&style.Template,
+		&style.CreatedAt,
+		&style.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []styleParameter
+	if err := json.Unmarshal(params, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode style parameters: %w", err)
+	}
+	for _, p := range stored {
+		style.Parameters = append(style.Parameters, domainStyle.Parameter(p))
+	}
+
+	style.Description = description.String
+	style.Layout = domainStyle.Layout(layout)
+
+	return &style, nil
+}
+
+func marshalStyleParameters(params []domainStyle.Parameter) ([]byte, error) {
+	stored := make([]styleParameter, 0, len(params))
+	for _, p := range params {
+		stored = append(stored, styleParameter(p))
+	}
+
+	encoded, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode style parameters: %w", err)
+	}
+	return encoded, nil
+}
@@ -0,0 +1,25 @@
+package database
+
+import (
+	"context"
+
+	"github.com/goIdioms/conspect-generator/internal/logging"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/goIdioms/conspect-generator/internal/infra/database")
+
+func startSpan(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	logging.FromContext(ctx).WithField("db_operation", name).Debug("Executing query")
+
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(name),
+			semconv.DBQueryText(query),
+		),
+	)
+}
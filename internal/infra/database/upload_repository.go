@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domainUpload "github.com/goIdioms/conspect-generator/internal/domain/upload"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+)
+
+const uploadColumns = `id, user_id, filename, content_type, metadata, length, upload_offset, created_at, updated_at, expires_at`
+
+type UploadRepository struct {
+	db *sql.DB
+}
+
+func NewUploadRepository(db *sql.DB) *UploadRepository {
+	return &UploadRepository{db: db}
+}
+
+func (r *UploadRepository) FindByID(ctx context.Context, id string) (*domainUpload.Upload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM uploads WHERE id = $1`
+	ctx, span := startSpan(ctx, "UploadRepository.FindByID", query)
+	defer span.End()
+
+	upload, err := scanUpload(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, domainUpload.ErrUploadNotFound
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find upload by ID: %w", err)
+	}
+
+	return upload, nil
+}
+
+func (r *UploadRepository) FindExpired(ctx context.Context, before time.Time) ([]*domainUpload.Upload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM uploads WHERE expires_at < $1`
+	ctx, span := startSpan(ctx, "UploadRepository.FindExpired", query)
+	defer span.End()
+
+	rows, err := r.db.QueryContext(ctx, query, before)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to find expired uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []*domainUpload.Upload
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			tracing.RecordError(span, err)
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+		uploads = append(uploads, upload)
+	}
+
+	if err := rows.Err(); err != nil {
+		tracing.RecordError(span, err)
+		return nil, fmt.Errorf("failed to iterate uploads: %w", err)
+	}
+
+	return uploads, nil
+}
+
+func (r *UploadRepository) Create(ctx context.Context, upload *domainUpload.Upload) error {
+	query := `
+		INSERT INTO uploads (id, user_id, filename, content_type, metadata, length, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+
+	ctx, span := startSpan(ctx, "UploadRepository.Create", query)
+	defer span.End()
+
+	metadata, err := json.Marshal(upload.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to encode upload metadata: %w", err)
+	}
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		upload.ID,
+		upload.UserID,
+		upload.Filename,
+		upload.ContentType,
+		metadata,
+		upload.Length,
+		upload.ExpiresAt,
+	).Scan(&upload.CreatedAt, &upload.UpdatedAt)
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to create upload: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UploadRepository) UpdateOffset(ctx context.Context, upload *domainUpload.Upload, from int64) error {
+	query := `
+		UPDATE uploads
+		SET upload_offset = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND upload_offset = $3
+		RETURNING updated_at
+	`
+
+	ctx, span := startSpan(ctx, "UploadRepository.UpdateOffset", query)
+	defer span.End()
+
+	err := r.db.QueryRowContext(ctx, query, upload.Offset, upload.ID, from).Scan(&upload.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return domainUpload.ErrOffsetMismatch
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to update upload offset: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UploadRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM uploads WHERE id = $1`
+	ctx, span := startSpan(ctx, "UploadRepository.Delete", query)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return domainUpload.ErrUploadNotFound
+	}
+
+	return nil
+}
+
+func scanUpload(row rowScanner) (*domainUpload.Upload, error) {
+	var (
+		upload   domainUpload.Upload
+		userID   sql.NullInt64
+		metadata []byte
+	)
+
+	err := row.Scan(
+		&upload.ID,
+		&userID,
+		&upload.Filename,
+		&upload.ContentType,
+		&metadata,
+		&upload.Length,
+		&upload.Offset,
+		&upload.CreatedAt,
+		&upload.UpdatedAt,
+		&upload.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		id := int(userID.Int64)
+		upload.UserID = &id
+	}
+	if err := json.Unmarshal(metadata, &upload.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode upload metadata: %w", err)
+	}
+
+	return &upload, nil
+}
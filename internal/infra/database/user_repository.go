@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	domainUser "github.com/goIdioms/conspect-generator/internal/domain/user"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
 )
 
 type UserRepository struct {
@@ -24,6 +25,9 @@ func (r *UserRepository) FindByID(ctx context.Context, id int) (*domainUser.User
 		WHERE id = $1
 	`
 
+	ctx, span := startSpan(ctx, "UserRepository.FindByID", query)
+	defer span.End()
+
 	var user domainUser.User
 	var emailStr, googleIDStr string
 
@@ -43,6 +47,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id int) (*domainUser.User
 		return nil, domainUser.ErrUserNotFound
 	}
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, fmt.Errorf("failed to find user by ID: %w", err)
 	}
 
@@ -60,6 +65,9 @@ func (r *UserRepository) FindByGoogleID(ctx context.Context, googleID domainUser
 		WHERE google_id = $1
 	`
 
+	ctx, span := startSpan(ctx, "UserRepository.FindByGoogleID", query)
+	defer span.End()
+
 	var user domainUser.User
 	var emailStr, googleIDStr string
 
@@ -79,6 +87,7 @@ func (r *UserRepository) FindByGoogleID(ctx context.Context, googleID domainUser
 		return nil, domainUser.ErrUserNotFound
 	}
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, fmt.Errorf("failed to find user by Google ID: %w", err)
 	}
 
@@ -96,6 +105,9 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email domainUser.Email
 		WHERE email = $1
 	`
 
+	ctx, span := startSpan(ctx, "UserRepository.FindByEmail", query)
+	defer span.End()
+
 	var user domainUser.User
 	var emailStr, googleIDStr string
 
@@ -115,6 +127,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email domainUser.Email
 		return nil, domainUser.ErrUserNotFound
 	}
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, fmt.Errorf("failed to find user by email: %w", err)
 	}
 
@@ -131,6 +144,9 @@ func (r *UserRepository) Create(ctx context.Context, user *domainUser.User) erro
 		RETURNING id, created_at, updated_at
 	`
 
+	ctx, span := startSpan(ctx, "UserRepository.Create", query)
+	defer span.End()
+
 	err := r.db.QueryRowContext(
 		ctx,
 		query,
@@ -143,6 +159,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domainUser.User) erro
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -158,6 +175,9 @@ func (r *UserRepository) Update(ctx context.Context, user *domainUser.User) erro
 		RETURNING updated_at
 	`
 
+	ctx, span := startSpan(ctx, "UserRepository.Update", query)
+	defer span.End()
+
 	err := r.db.QueryRowContext(
 		ctx,
 		query,
@@ -170,6 +190,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domainUser.User) erro
 	).Scan(&user.UpdatedAt)
 
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -178,13 +199,18 @@ func (r *UserRepository) Update(ctx context.Context, user *domainUser.User) erro
 
 func (r *UserRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM users WHERE id = $1`
+	ctx, span := startSpan(ctx, "UserRepository.Delete", query)
+	defer span.End()
+
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
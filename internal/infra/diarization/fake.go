@@ -0,0 +1,33 @@
+package diarization
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+type FakeDiarizer struct {
+	speakers   int
+	turnLength time.Duration
+}
+
+func NewFakeDiarizer(speakers int, turnLength time.Duration) *FakeDiarizer {
+	return &FakeDiarizer{
+		speakers:   max(speakers, 1),
+		turnLength: turnLength,
+	}
+}
+
+func (d *FakeDiarizer) Diarize(_ context.Context, _ string, duration time.Duration) ([]domainConspect.SpeakerTurn, error) {
+	var turns []domainConspect.SpeakerTurn
+	for start, i := time.Duration(0), 0; start < duration; start, i = start+d.turnLength, i+1 {
+		turns = append(turns, domainConspect.SpeakerTurn{
+			Speaker: fmt.Sprintf("SPEAKER_%02d", i%d.speakers),
+			Start:   start,
+			End:     min(start+d.turnLength, duration),
+		})
+	}
+	return turns, nil
+}
@@ -0,0 +1,91 @@
+package diarization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+)
+
+type HTTPDiarizer struct {
+	url    string
+	client *http.Client
+}
+
+type diarizationResponse struct {
+	Segments []struct {
+		Speaker string  `json:"speaker"`
+		Start   float64 `json:"start"`
+		End     float64 `json:"end"`
+	} `json:"segments"`
+}
+
+func NewHTTPDiarizer(url string, timeout time.Duration) *HTTPDiarizer {
+	client := tracing.HTTPClient()
+	client.Timeout = timeout
+
+	return &HTTPDiarizer{
+		url:    url,
+		client: client,
+	}
+}
+
+func (d *HTTPDiarizer) Diarize(ctx context.Context, filePath string, _ time.Duration) ([]domainConspect.SpeakerTurn, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio: %w", err)
+	}
+	defer file.Close()
+
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile("file", filepath.Base(filePath))
+		if err == nil {
+			_, err = io.Copy(part, file)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create diarization request: %w", err)
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call diarization service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("diarization service returned %s", resp.Status)
+	}
+
+	var decoded diarizationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode diarization response: %w", err)
+	}
+
+	turns := make([]domainConspect.SpeakerTurn, 0, len(decoded.Segments))
+	for _, segment := range decoded.Segments {
+		turns = append(turns, domainConspect.SpeakerTurn{
+			Speaker: segment.Speaker,
+			Start:   time.Duration(segment.Start * float64(time.Second)),
+			End:     time.Duration(segment.End * float64(time.Second)),
+		})
+	}
+	return turns, nil
+}
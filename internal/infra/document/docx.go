@@ -0,0 +1,183 @@
+package document
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+const (
+	docxBodyPath       = "word/document.xml"
+	wordNamespace      = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	headingStylePrefix = "heading"
+)
+
+var errDecompressedTooLarge = errors.New("document body exceeds the maximum decompressed size")
+
+type docxParagraph struct {
+	text    strings.Builder
+	heading int
+	list    bool
+}
+
+func (p *docxParagraph) String() string {
+	text := strings.TrimSpace(p.text.String())
+	switch {
+	case text == "":
+		return ""
+	case p.heading > 0:
+		return strings.Repeat("#", min(p.heading, 6)) + " " + text
+	case p.list:
+		return "- " + text
+	default:
+		return text
+	}
+}
+
+func extractDOCX(filePath string) (string, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domainConspect.ErrUnreadableDocument, err)
+	}
+	defer archive.Close()
+
+	var body *zip.File
+	for _, file := range archive.File {
+		if file.Name == docxBodyPath {
+			body = file
+			break
+		}
+	}
+	if body == nil {
+		return "", fmt.Errorf("%w: %s is missing", domainConspect.ErrUnreadableDocument, docxBodyPath)
+	}
+	if body.UncompressedSize64 > maxDecompressedSize {
+		return "", fmt.Errorf("%w: %w", domainConspect.ErrUnreadableDocument, errDecompressedTooLarge)
+	}
+
+	reader, err := body.Open()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domainConspect.ErrUnreadableDocument, err)
+	}
+	defer reader.Close()
+
+	text, err := parseDocumentXML(&limitedReader{r: reader, remaining: maxDecompressedSize})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domainConspect.ErrUnreadableDocument, err)
+	}
+	return text, nil
+}
+
+func parseDocumentXML(r io.Reader) (string, error) {
+	decoder := xml.NewDecoder(r)
+
+	var paragraphs []string
+	var paragraph *docxParagraph
+	inText := false
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Space != wordNamespace {
+				continue
+			}
+			switch t.Name.Local {
+			case "p":
+				paragraph = &docxParagraph{}
+			case "pStyle":
+				if paragraph != nil {
+					paragraph.heading = headingLevel(attr(t, "val"))
+				}
+			case "numPr":
+				if paragraph != nil {
+					paragraph.list = true
+				}
+			case "t":
+				inText = true
+			case "tab":
+				if paragraph != nil {
+					paragraph.text.WriteByte('\t')
+				}
+			case "br", "cr":
+				if paragraph != nil {
+					paragraph.text.WriteByte('\n')
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Space != wordNamespace {
+				continue
+			}
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				if paragraph != nil {
+					if text := paragraph.String(); text != "" {
+						paragraphs = append(paragraphs, text)
+					}
+				}
+				paragraph = nil
+			}
+		case xml.CharData:
+			if inText && paragraph != nil {
+				paragraph.text.Write(t)
+			}
+		}
+	}
+
+	return strings.Join(paragraphs, "\n\n"), nil
+}
+
+func headingLevel(style string) int {
+	style = strings.ToLower(style)
+	if !strings.HasPrefix(style, headingStylePrefix) {
+		return 0
+	}
+	level := 0
+	for _, r := range style[len(headingStylePrefix):] {
+		if r < '0' || r > '9' {
+			return 0
+		}
+		level = level*10 + int(r-'0')
+	}
+	return max(level, 1)
+}
+
+func attr(element xml.StartElement, name string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, errDecompressedTooLarge
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,93 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	maxDecompressedSize = 64 * 1024 * 1024
+	maxErrorOutput      = 512
+)
+
+var tracer = tracing.Tracer("github.com/goIdioms/conspect-generator/internal/infra/document")
+
+type Extractor struct {
+	pdftotextPath string
+	timeout       time.Duration
+	maxTextSize   int64
+}
+
+func NewExtractor(pdftotextPath string, timeout time.Duration, maxTextSize int64) *Extractor {
+	return &Extractor{
+		pdftotextPath: pdftotextPath,
+		timeout:       timeout,
+		maxTextSize:   maxTextSize,
+	}
+}
+
+func (e *Extractor) Supports(format domainConspect.DocumentFormat) bool {
+	if format == domainConspect.DocumentPDF {
+		return e.pdftotextPath != ""
+	}
+	return format.IsText() || format == domainConspect.DocumentDOCX
+}
+
+func (e *Extractor) Extract(ctx context.Context, filePath string, format domainConspect.DocumentFormat) (domainConspect.Transcript, error) {
+	ctx, span := tracer.Start(ctx, "document.extract")
+	defer span.End()
+	span.SetAttributes(attribute.String("document.format", string(format)))
+
+	transcript, err := e.extract(ctx, filePath, format)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return domainConspect.Transcript{}, err
+	}
+
+	transcript.Text = strings.TrimSpace(transcript.Text)
+	if transcript.Text == "" {
+		return domainConspect.Transcript{}, domainConspect.ErrEmptyDocument
+	}
+	span.SetAttributes(
+		attribute.Int("document.chars", len([]rune(transcript.Text))),
+		attribute.Int("document.segments", len(transcript.Segments)),
+	)
+
+	return transcript, nil
+}
+
+func (e *Extractor) extract(ctx context.Context, filePath string, format domainConspect.DocumentFormat) (domainConspect.Transcript, error) {
+	switch format {
+	case domainConspect.DocumentDOCX:
+		text, err := extractDOCX(filePath)
+		return domainConspect.Transcript{Text: text}, err
+	case domainConspect.DocumentPDF:
+		text, err := e.extractPDF(ctx, filePath)
+		return domainConspect.Transcript{Text: text}, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return domainConspect.Transcript{}, fmt.Errorf("failed to read document: %w", err)
+	}
+	text, err := decodeText(data)
+	if err != nil {
+		return domainConspect.Transcript{}, err
+	}
+
+	switch format {
+	case domainConspect.DocumentSRT, domainConspect.DocumentVTT:
+		return parseSubtitles(text), nil
+	case domainConspect.DocumentText, domainConspect.DocumentMarkdown:
+		return domainConspect.Transcript{Text: text}, nil
+	default:
+		return domainConspect.Transcript{}, fmt.Errorf("unsupported document format: %s", format)
+	}
+}
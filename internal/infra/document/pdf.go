@@ -0,0 +1,66 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+var errTextTooLarge = errors.New("extracted text exceeds the maximum size")
+
+func (e *Extractor) extractPDF(ctx context.Context, filePath string) (string, error) {
+	if e.pdftotextPath == "" {
+		return "", fmt.Errorf("pdf extraction is not configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	stdout := &limitedWriter{remaining: e.maxTextSize, cancel: cancel}
+	cmd := exec.CommandContext(ctx, e.pdftotextPath, "-enc", "UTF-8", "-nopgbrk", filePath, "-")
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stdout.exceeded {
+			return "", fmt.Errorf("%w: %w", domainConspect.ErrUnreadableDocument, errTextTooLarge)
+		}
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("pdftotext was interrupted: %w", ctx.Err())
+		}
+		return "", fmt.Errorf("%w: %v: %s", domainConspect.ErrUnreadableDocument, err, tail(stderr.String()))
+	}
+
+	return strings.ReplaceAll(stdout.buf.String(), "\f", "\n"), nil
+}
+
+type limitedWriter struct {
+	buf       bytes.Buffer
+	remaining int64
+	exceeded  bool
+	cancel    context.CancelFunc
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > w.remaining {
+		w.exceeded = true
+		w.cancel()
+		return 0, errTextTooLarge
+	}
+	w.remaining -= int64(len(p))
+	return w.buf.Write(p)
+}
+
+func tail(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxErrorOutput {
+		output = "..." + output[len(output)-maxErrorOutput:]
+	}
+	return output
+}
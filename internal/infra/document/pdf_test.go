@@ -0,0 +1,71 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+func fakePDFToText(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pdftotext")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o700); err != nil {
+		t.Fatalf("write fake pdftotext: %v", err)
+	}
+	return path
+}
+
+func TestExtractPDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		want    string
+		wantErr []error
+	}{
+		{
+			name:   "joins pages",
+			script: `printf 'page one\fpage two'`,
+			want:   "page one\npage two",
+		},
+		{
+			name:    "reports failures",
+			script:  `echo 'Syntax Error: broken xref' >&2; exit 1`,
+			wantErr: []error{domainConspect.ErrUnreadableDocument},
+		},
+		{
+			name:    "stops endless output",
+			script:  `exec yes lecture`,
+			wantErr: []error{domainConspect.ErrUnreadableDocument, errTextTooLarge},
+		},
+		{
+			name:    "rejects output over the limit",
+			script:  `head -c 1025 /dev/zero`,
+			wantErr: []error{domainConspect.ErrUnreadableDocument, errTextTooLarge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewExtractor(fakePDFToText(t, tt.script), 10*time.Second, 1024)
+
+			start := time.Now()
+			got, err := e.extractPDF(context.Background(), "lecture.pdf")
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Fatalf("extractPDF() took %s", elapsed)
+			}
+			for _, want := range tt.wantErr {
+				if !errors.Is(err, want) {
+					t.Fatalf("extractPDF() error = %v, want %v", err, want)
+				}
+			}
+			if tt.wantErr == nil && (err != nil || got != tt.want) {
+				t.Fatalf("extractPDF() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
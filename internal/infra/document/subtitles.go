@@ -0,0 +1,97 @@
+package document
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+const cueArrow = "-->"
+
+var (
+	blankLinePattern = regexp.MustCompile(`\n[ \t]*\n`)
+	voiceTagPattern  = regexp.MustCompile(`<v(?:\.[^ >]*)?\s+([^>]+)>`)
+	markupPattern    = regexp.MustCompile(`<[^>]*>|\{\\[^}]*\}`)
+	clockPattern     = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{2})[.,](\d{1,3})$`)
+)
+
+func parseSubtitles(text string) domainConspect.Transcript {
+	var transcript domainConspect.Transcript
+	var lines []string
+
+	for _, block := range blankLinePattern.Split(strings.TrimSpace(text), -1) {
+		segment, ok := parseCue(strings.Split(block, "\n"))
+		if !ok {
+			continue
+		}
+		segment.Position = len(transcript.Segments)
+		transcript.Segments = append(transcript.Segments, segment)
+		transcript.Duration = max(transcript.Duration, segment.End)
+		lines = append(lines, segment.Text)
+	}
+
+	transcript.Text = strings.Join(lines, "\n")
+	return transcript
+}
+
+func parseCue(lines []string) (domainConspect.Segment, bool) {
+	timing := -1
+	for i, line := range lines {
+		if strings.Contains(line, cueArrow) {
+			timing = i
+			break
+		}
+	}
+	if timing < 0 {
+		return domainConspect.Segment{}, false
+	}
+
+	from, rest, _ := strings.Cut(lines[timing], cueArrow)
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return domainConspect.Segment{}, false
+	}
+	start, okStart := parseClock(strings.TrimSpace(from))
+	end, okEnd := parseClock(fields[0])
+	if !okStart || !okEnd {
+		return domainConspect.Segment{}, false
+	}
+
+	var text []string
+	for _, line := range lines[timing+1:] {
+		line = voiceTagPattern.ReplaceAllString(line, "$1: ")
+		line = strings.Join(strings.Fields(markupPattern.ReplaceAllString(line, "")), " ")
+		if line != "" {
+			text = append(text, line)
+		}
+	}
+	if len(text) == 0 {
+		return domainConspect.Segment{}, false
+	}
+
+	return domainConspect.Segment{
+		Start: start,
+		End:   max(start, end),
+		Text:  strings.Join(text, " "),
+	}, true
+}
+
+func parseClock(value string) (time.Duration, bool) {
+	match := clockPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, false
+	}
+
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	millis, _ := strconv.Atoi((match[4] + "00")[:3])
+
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(millis)*time.Millisecond, true
+}
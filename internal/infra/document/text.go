@@ -0,0 +1,46 @@
+package document
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	xunicode "golang.org/x/text/encoding/unicode"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+func decodeText(data []byte) (string, error) {
+	var decoder *encoding.Decoder
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		data = data[len(bomUTF8):]
+	case bytes.HasPrefix(data, bomUTF16LE):
+		decoder = xunicode.UTF16(xunicode.LittleEndian, xunicode.ExpectBOM).NewDecoder()
+	case bytes.HasPrefix(data, bomUTF16BE):
+		decoder = xunicode.UTF16(xunicode.BigEndian, xunicode.ExpectBOM).NewDecoder()
+	case !utf8.Valid(data):
+		decoder = charmap.Windows1251.NewDecoder()
+	}
+
+	if decoder != nil {
+		decoded, err := decoder.Bytes(data)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", domainConspect.ErrUnreadableDocument, err)
+		}
+		data = decoded
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", fmt.Errorf("%w: binary content", domainConspect.ErrUnreadableDocument)
+	}
+
+	return strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(string(data)), nil
+}
@@ -0,0 +1,181 @@
+package media
+
+import (
+	"bytes"
+	"io"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+const (
+	id3HeaderSize  = 10
+	id3v1Size      = 128
+	mp3SyncWindow  = 64 * 1024
+	mpegVersion1   = 3
+	mpegVersion2   = 2
+	mpegVersion25  = 0
+	mpegLayer1     = 3
+	mpegLayer2     = 2
+	mpegLayer3     = 1
+	xingFramesFlag = 1
+	vbriOffset     = 32
+)
+
+var (
+	mpeg1Bitrates = [4][16]int{
+		mpegLayer1: {0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+		mpegLayer2: {0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+		mpegLayer3: {0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+	}
+	mpeg2Bitrates = [4][16]int{
+		mpegLayer1: {0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+		mpegLayer2: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+		mpegLayer3: {0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+	}
+	mpegSampleRates = [4][3]int{
+		mpegVersion1:  {44100, 48000, 32000},
+		mpegVersion2:  {22050, 24000, 16000},
+		mpegVersion25: {11025, 12000, 8000},
+	}
+)
+
+type mp3Frame struct {
+	version    int
+	layer      int
+	bitrate    int
+	sampleRate int
+	channels   int
+}
+
+func probeMP3(r io.ReaderAt, size int64) (domainConspect.AudioInfo, error) {
+	info := domainConspect.AudioInfo{Container: "mp3", Codec: "mp3"}
+
+	start, err := skipID3(r, size)
+	if err != nil {
+		return info, err
+	}
+
+	window, err := readAt(r, start, int(min(mp3SyncWindow, size-start)))
+	if err != nil {
+		return info, corrupt("mp3: %v", err)
+	}
+
+	offset := -1
+	var frame mp3Frame
+	for i := 0; i+4 <= len(window); i++ {
+		if !isFrameSync(window[i:]) {
+			continue
+		}
+		if parsed, ok := parseMP3Frame(window[i : i+4]); ok {
+			offset, frame = i, parsed
+			break
+		}
+	}
+	if offset < 0 {
+		return info, corrupt("mp3: no valid frame header")
+	}
+
+	info.SampleRate = frame.sampleRate
+	info.Channels = frame.channels
+
+	samplesPerFrame := 1152
+	switch {
+	case frame.layer == mpegLayer1:
+		samplesPerFrame = 384
+	case frame.layer == mpegLayer3 && frame.version != mpegVersion1:
+		samplesPerFrame = 576
+	}
+
+	if frames, ok := vbrFrames(window[offset:], frame); ok {
+		info.Duration = seconds(float64(frames)*float64(samplesPerFrame), float64(frame.sampleRate))
+		return info, nil
+	}
+
+	audioBytes := size - start - int64(offset)
+	if tag, err := readAt(r, size-id3v1Size, 3); err == nil && string(tag) == "TAG" {
+		audioBytes -= id3v1Size
+	}
+	info.Duration = seconds(float64(audioBytes)*8, float64(frame.bitrate*1000))
+	return info, nil
+}
+
+func skipID3(r io.ReaderAt, size int64) (int64, error) {
+	header, err := readAt(r, 0, id3HeaderSize)
+	if err != nil || !bytes.HasPrefix(header, []byte("ID3")) {
+		return 0, nil
+	}
+
+	var tagSize int64
+	for _, b := range header[6:10] {
+		if b&0x80 != 0 {
+			return 0, corrupt("mp3: invalid ID3 tag size")
+		}
+		tagSize = tagSize<<7 | int64(b)
+	}
+
+	tagSize += id3HeaderSize
+	if header[5]&0x10 != 0 {
+		tagSize += id3HeaderSize
+	}
+	if tagSize >= size {
+		return 0, corrupt("mp3: ID3 tag runs past the end of the file")
+	}
+	return tagSize, nil
+}
+
+func isFrameSync(b []byte) bool {
+	return b[0] == 0xFF && b[1]&0xE0 == 0xE0
+}
+
+func parseMP3Frame(header []byte) (mp3Frame, bool) {
+	frame := mp3Frame{
+		version: int(header[1]>>3) & 3,
+		layer:   int(header[1]>>1) & 3,
+	}
+	bitrateIndex := int(header[2] >> 4)
+	rateIndex := int(header[2]>>2) & 3
+
+	if frame.version == 1 || frame.layer == 0 || rateIndex == 3 {
+		return frame, false
+	}
+
+	if frame.version == mpegVersion1 {
+		frame.bitrate = mpeg1Bitrates[frame.layer][bitrateIndex]
+	} else {
+		frame.bitrate = mpeg2Bitrates[frame.layer][bitrateIndex]
+	}
+	if frame.bitrate == 0 {
+		return frame, false
+	}
+
+	frame.sampleRate = mpegSampleRates[frame.version][rateIndex]
+	frame.channels = 2
+	if header[3]>>6 == 3 {
+		frame.channels = 1
+	}
+	return frame, true
+}
+
+func vbrFrames(frame []byte, header mp3Frame) (uint32, bool) {
+	sideInfo := 32
+	switch {
+	case header.version == mpegVersion1 && header.channels == 1:
+		sideInfo = 17
+	case header.version != mpegVersion1 && header.channels == 1:
+		sideInfo = 9
+	case header.version != mpegVersion1:
+		sideInfo = 17
+	}
+
+	if xing := 4 + sideInfo; len(frame) >= xing+12 {
+		tag := string(frame[xing : xing+4])
+		if (tag == "Xing" || tag == "Info") && be32(frame[xing+4:])&xingFramesFlag != 0 {
+			return be32(frame[xing+8:]), true
+		}
+	}
+
+	if vbri := 4 + vbriOffset; len(frame) >= vbri+18 && string(frame[vbri:vbri+4]) == "VBRI" {
+		return be32(frame[vbri+14:]), true
+	}
+	return 0, false
+}
@@ -0,0 +1,155 @@
+package media
+
+import (
+	"encoding/binary"
+	"io"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+const (
+	boxHeaderSize      = 8
+	largeBoxHeaderSize = 16
+	audioEntryOffset   = 16
+)
+
+type box struct {
+	kind   string
+	offset int64
+	size   int64
+	header int64
+}
+
+func probeMP4(r io.ReaderAt, size int64) (domainConspect.AudioInfo, error) {
+	info := domainConspect.AudioInfo{Container: "mp4"}
+
+	moov, ok, err := findBox(r, 0, size, "moov")
+	if err != nil {
+		return info, err
+	}
+	if !ok {
+		return info, corrupt("mp4: missing moov box")
+	}
+
+	mvhd, ok, err := findBox(r, moov.offset+moov.header, moov.offset+moov.size, "mvhd")
+	if err != nil {
+		return info, err
+	}
+	if !ok {
+		return info, corrupt("mp4: missing mvhd box")
+	}
+	header, err := readAt(r, mvhd.offset+mvhd.header, int(min(mvhd.size-mvhd.header, 32)))
+	if err != nil || len(header) < 20 {
+		return info, corrupt("mp4: truncated mvhd box")
+	}
+	if header[0] == 1 {
+		if len(header) < 32 {
+			return info, corrupt("mp4: truncated mvhd box")
+		}
+		info.Duration = seconds(float64(binary.BigEndian.Uint64(header[24:])), float64(be32(header[20:])))
+	} else {
+		info.Duration = seconds(float64(be32(header[16:])), float64(be32(header[12:])))
+	}
+
+	if err := probeMP4Tracks(r, moov, &info); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+func probeMP4Tracks(r io.ReaderAt, moov box, info *domainConspect.AudioInfo) error {
+	return walkBoxes(r, moov.offset+moov.header, moov.offset+moov.size, func(trak box) (bool, error) {
+		if trak.kind != "trak" {
+			return false, nil
+		}
+
+		mdia, ok, err := findPath(r, trak, "mdia")
+		if err != nil || !ok {
+			return false, err
+		}
+		hdlr, ok, err := findPath(r, mdia, "hdlr")
+		if err != nil || !ok {
+			return false, err
+		}
+		handler, err := readAt(r, hdlr.offset+hdlr.header+8, 4)
+		if err != nil {
+			return false, nil
+		}
+		if string(handler) == "vide" {
+			info.Video = true
+			return false, nil
+		}
+		if string(handler) != "soun" || info.Codec != "" {
+			return false, nil
+		}
+
+		stsd, ok, err := findPath(r, mdia, "minf", "stbl", "stsd")
+		if err != nil || !ok {
+			return false, err
+		}
+		entry, err := readAt(r, stsd.offset+stsd.header+8, boxHeaderSize+audioEntryOffset+12)
+		if err != nil {
+			return false, corrupt("mp4: truncated stsd box")
+		}
+		info.Codec = string(entry[4:8])
+		info.Channels = int(binary.BigEndian.Uint16(entry[boxHeaderSize+audioEntryOffset:]))
+		info.SampleRate = int(be32(entry[boxHeaderSize+audioEntryOffset+8:]) >> 16)
+		return false, nil
+	})
+}
+
+func findPath(r io.ReaderAt, parent box, path ...string) (box, bool, error) {
+	current := parent
+	for _, kind := range path {
+		child, ok, err := findBox(r, current.offset+current.header, current.offset+current.size, kind)
+		if err != nil || !ok {
+			return child, ok, err
+		}
+		current = child
+	}
+	return current, true, nil
+}
+
+func findBox(r io.ReaderAt, start, end int64, kind string) (box, bool, error) {
+	var found box
+	err := walkBoxes(r, start, end, func(b box) (bool, error) {
+		if b.kind == kind {
+			found = b
+			return true, nil
+		}
+		return false, nil
+	})
+	return found, found.kind == kind, err
+}
+
+func walkBoxes(r io.ReaderAt, start, end int64, visit func(box) (bool, error)) error {
+	for offset := start; offset+boxHeaderSize <= end; {
+		header, err := readAt(r, offset, boxHeaderSize)
+		if err != nil {
+			return corrupt("mp4: %v", err)
+		}
+
+		b := box{kind: string(header[4:8]), offset: offset, size: int64(be32(header)), header: boxHeaderSize}
+		switch b.size {
+		case 0:
+			b.size = end - offset
+		case 1:
+			large, err := readAt(r, offset+boxHeaderSize, 8)
+			if err != nil {
+				return corrupt("mp4: truncated box size")
+			}
+			b.size = int64(binary.BigEndian.Uint64(large))
+			b.header = largeBoxHeaderSize
+		}
+		if b.size < b.header || b.size > end-offset {
+			return corrupt("mp4: box %q overflows its parent", b.kind)
+		}
+
+		stop, err := visit(b)
+		if err != nil || stop {
+			return err
+		}
+		offset += b.size
+	}
+	return nil
+}
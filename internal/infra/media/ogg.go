@@ -0,0 +1,91 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+const (
+	oggPageHeaderSize = 27
+	oggTailWindow     = 64 * 1024
+	opusSampleRate    = 48000
+)
+
+var oggCapture = []byte("OggS")
+
+func probeOgg(r io.ReaderAt, size int64) (domainConspect.AudioInfo, error) {
+	info := domainConspect.AudioInfo{Container: "ogg"}
+
+	page, err := readAt(r, 0, oggPageHeaderSize)
+	if err != nil {
+		return info, corrupt("ogg: %v", err)
+	}
+	serial := le32(page[14:])
+	segments := int(page[26])
+
+	table, err := readAt(r, oggPageHeaderSize, segments)
+	if err != nil {
+		return info, corrupt("ogg: truncated segment table")
+	}
+	var packetSize int
+	for _, lacing := range table {
+		packetSize += int(lacing)
+	}
+
+	packet, err := readAt(r, int64(oggPageHeaderSize+segments), packetSize)
+	if err != nil {
+		return info, corrupt("ogg: truncated first packet")
+	}
+
+	var preSkip uint64
+	switch {
+	case bytes.HasPrefix(packet, []byte("\x01vorbis")) && len(packet) >= 16:
+		info.Codec = "vorbis"
+		info.Channels = int(packet[11])
+		info.SampleRate = int(le32(packet[12:]))
+	case bytes.HasPrefix(packet, []byte("OpusHead")) && len(packet) >= 16:
+		info.Codec = "opus"
+		info.Channels = int(packet[9])
+		info.SampleRate = opusSampleRate
+		preSkip = uint64(binary.LittleEndian.Uint16(packet[10:]))
+	default:
+		return info, nil
+	}
+	if info.SampleRate == 0 {
+		return info, corrupt("ogg: zero sample rate")
+	}
+
+	granule, ok, err := lastGranule(r, size, serial)
+	if err != nil {
+		return info, corrupt("ogg: %v", err)
+	}
+	if !ok {
+		return info, corrupt("ogg: no final page")
+	}
+	if granule > preSkip {
+		info.Duration = seconds(float64(granule-preSkip), float64(info.SampleRate))
+	}
+	return info, nil
+}
+
+func lastGranule(r io.ReaderAt, size int64, serial uint32) (uint64, bool, error) {
+	start := max(size-oggTailWindow, 0)
+	tail, err := readAt(r, start, int(size-start))
+	if err != nil {
+		return 0, false, err
+	}
+
+	for i := bytes.LastIndex(tail, oggCapture); i >= 0; i = bytes.LastIndex(tail[:i], oggCapture) {
+		if len(tail)-i < oggPageHeaderSize || le32(tail[i+14:]) != serial {
+			continue
+		}
+		granule := binary.LittleEndian.Uint64(tail[i+6:])
+		if granule != ^uint64(0) {
+			return granule, true, nil
+		}
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,74 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+const headerSize = 12
+
+var ErrUnknownFormat = errors.New("unknown media format")
+
+type prober func(r io.ReaderAt, size int64) (domainConspect.AudioInfo, error)
+
+func Probe(r io.ReaderAt, size int64) (domainConspect.AudioInfo, error) {
+	header := make([]byte, headerSize)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return domainConspect.AudioInfo{}, fmt.Errorf("failed to read media header: %w", err)
+	}
+	header = header[:n]
+
+	var probe prober
+	switch {
+	case bytes.HasPrefix(header, []byte("RIFF")) && len(header) == headerSize && string(header[8:12]) == "WAVE":
+		probe = probeWAV
+	case bytes.HasPrefix(header, []byte("OggS")):
+		probe = probeOgg
+	case bytes.HasPrefix(header, ebmlMagic):
+		probe = probeWebM
+	case len(header) >= 8 && string(header[4:8]) == "ftyp":
+		probe = probeMP4
+	case bytes.HasPrefix(header, []byte("ID3")) || len(header) >= 2 && isFrameSync(header):
+		probe = probeMP3
+	default:
+		return domainConspect.AudioInfo{}, ErrUnknownFormat
+	}
+
+	return probe(r, size)
+}
+
+func corrupt(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", domainConspect.ErrUndecodableAudio, fmt.Sprintf(format, args...))
+}
+
+func readAt(r io.ReaderAt, offset int64, n int) ([]byte, error) {
+	if n < 0 || offset < 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	buf := make([]byte, n)
+	read, err := r.ReadAt(buf, offset)
+	if read == n {
+		return buf, nil
+	}
+	if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return nil, err
+}
+
+func seconds(value, rate float64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+	return time.Duration(value / rate * float64(time.Second))
+}
+
+func be32(b []byte) uint32 { return binary.BigEndian.Uint32(b) }
+func le32(b []byte) uint32 { return binary.LittleEndian.Uint32(b) }
@@ -0,0 +1,319 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+func wavFile(dataSize int) []byte {
+	format := make([]byte, fmtChunkSize)
+	binary.LittleEndian.PutUint16(format, 1)
+	binary.LittleEndian.PutUint16(format[2:], 2)
+	binary.LittleEndian.PutUint32(format[4:], 44100)
+	binary.LittleEndian.PutUint32(format[8:], 44100*4)
+	binary.LittleEndian.PutUint16(format[12:], 4)
+	binary.LittleEndian.PutUint16(format[14:], 16)
+
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+	body.Write(riffChunk("fmt ", format))
+	body.Write(riffChunk("data", make([]byte, dataSize)))
+	return riffChunk("RIFF", body.Bytes())
+}
+
+func riffChunk(id string, payload []byte) []byte {
+	chunk := make([]byte, chunkHeaderSize, chunkHeaderSize+len(payload))
+	copy(chunk, id)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(payload)))
+	return append(chunk, payload...)
+}
+
+const (
+	mp3FrameSize = 417
+	mp3Bitrate   = 128000
+)
+
+func mp3File(frames int, id3 []byte) []byte {
+	frame := make([]byte, mp3FrameSize)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+	return append(id3, bytes.Repeat(frame, frames)...)
+}
+
+func xingMP3File(frames uint32) []byte {
+	frame := make([]byte, mp3FrameSize)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+	copy(frame[36:], "Xing")
+	binary.BigEndian.PutUint32(frame[40:], xingFramesFlag)
+	binary.BigEndian.PutUint32(frame[44:], frames)
+	return bytes.Repeat(frame, 3)
+}
+
+func id3Tag(payload int) []byte {
+	tag := make([]byte, id3HeaderSize+payload)
+	copy(tag, "ID3\x03\x00\x00")
+	for i := 0; i < 4; i++ {
+		tag[9-i] = byte(payload >> (7 * i) & 0x7F)
+	}
+	return tag
+}
+
+func oggPage(serial uint32, granule uint64, packet []byte) []byte {
+	page := make([]byte, oggPageHeaderSize)
+	copy(page, oggCapture)
+	binary.LittleEndian.PutUint64(page[6:], granule)
+	binary.LittleEndian.PutUint32(page[14:], serial)
+	page[26] = 1
+	page = append(page, byte(len(packet)))
+	return append(page, packet...)
+}
+
+func opusFile(granule uint64) []byte {
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1
+	head[9] = 2
+	binary.LittleEndian.PutUint16(head[10:], 312)
+	binary.LittleEndian.PutUint32(head[12:], 48000)
+	return append(oggPage(7, 0, head), oggPage(7, granule, make([]byte, 40))...)
+}
+
+func vorbisFile(granule uint64) []byte {
+	head := make([]byte, 30)
+	copy(head, "\x01vorbis")
+	head[11] = 1
+	binary.LittleEndian.PutUint32(head[12:], 22050)
+	return append(oggPage(3, 0, head), oggPage(3, granule, make([]byte, 40))...)
+}
+
+func mp4Box(kind string, children ...[]byte) []byte {
+	b := make([]byte, boxHeaderSize)
+	copy(b[4:], kind)
+	for _, child := range children {
+		b = append(b, child...)
+	}
+	binary.BigEndian.PutUint32(b, uint32(len(b)))
+	return b
+}
+
+func mp4File(timescale, duration uint32, handler string) []byte {
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], timescale)
+	binary.BigEndian.PutUint32(mvhd[16:], duration)
+
+	hdlr := make([]byte, 24)
+	copy(hdlr[8:], handler)
+
+	entry := make([]byte, 36)
+	binary.BigEndian.PutUint32(entry, 36)
+	copy(entry[4:], "mp4a")
+	binary.BigEndian.PutUint16(entry[24:], 2)
+	binary.BigEndian.PutUint32(entry[32:], 44100<<16)
+	stsd := append(make([]byte, 8), entry...)
+
+	trak := mp4Box("trak", mp4Box("mdia", mp4Box("hdlr", hdlr), mp4Box("minf", mp4Box("stbl", mp4Box("stsd", stsd)))))
+	return append(mp4Box("ftyp", []byte("M4A \x00\x00\x00\x00")), mp4Box("moov", mp4Box("mvhd", mvhd), trak)...)
+}
+
+func ebml(id uint32, children ...[]byte) []byte {
+	var payload []byte
+	for _, child := range children {
+		payload = append(payload, child...)
+	}
+
+	var out []byte
+	for shift := 24; shift >= 0; shift -= 8 {
+		if b := byte(id >> shift); b != 0 || len(out) > 0 {
+			out = append(out, b)
+		}
+	}
+	if len(payload) < 0x7F {
+		out = append(out, 0x80|byte(len(payload)))
+	} else {
+		out = append(out, 0x40|byte(len(payload)>>8), byte(len(payload)))
+	}
+	return append(out, payload...)
+}
+
+func ebmlUint(id uint32, value uint64) []byte {
+	return ebml(id, binary.BigEndian.AppendUint64(nil, value))
+}
+
+func ebmlFloat(id uint32, value float64) []byte {
+	return ebml(id, binary.BigEndian.AppendUint64(nil, math.Float64bits(value)))
+}
+
+func webmFile(trackType uint64, codec string) []byte {
+	header := ebml(ebmlIDHeader, ebml(ebmlIDDocType, []byte("webm")))
+	info := ebml(ebmlIDInfo, ebmlUint(ebmlIDTimecodeScale, ebmlDefaultTimescale), ebmlFloat(ebmlIDDuration, 5000))
+	track := ebml(ebmlIDTrackEntry,
+		ebmlUint(ebmlIDTrackType, trackType),
+		ebml(ebmlIDCodecID, []byte(codec)),
+		ebml(ebmlIDAudio, ebmlFloat(ebmlIDSamplingRate, 48000), ebmlUint(ebmlIDChannels, 2)),
+	)
+	return append(header, ebml(ebmlIDSegment, info, ebml(ebmlIDTracks, track))...)
+}
+
+func unknownSizeWebM() []byte {
+	file := webmFile(ebmlTrackTypeAudio, "A_OPUS")
+	header := ebml(ebmlIDHeader, ebml(ebmlIDDocType, []byte("webm")))
+	segment := file[len(header):]
+	sizeLength := 1
+	if segment[4]&0x80 == 0 {
+		sizeLength = 2
+	}
+	out := append([]byte{}, header...)
+	out = append(out, segment[:4]...)
+	out = append(out, 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+	return append(out, segment[4+sizeLength:]...)
+}
+
+func probeBytes(data []byte) (domainConspect.AudioInfo, error) {
+	return Probe(bytes.NewReader(data), int64(len(data)))
+}
+
+func TestProbeParsesContainers(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want domainConspect.AudioInfo
+	}{
+		{
+			name: "wav",
+			data: wavFile(44100 * 4 * 2),
+			want: domainConspect.AudioInfo{Container: "wav", Codec: "pcm", Duration: 2 * time.Second, SampleRate: 44100, Channels: 2},
+		},
+		{
+			name: "cbr mp3",
+			data: mp3File(100, nil),
+			want: domainConspect.AudioInfo{Container: "mp3", Codec: "mp3", Duration: seconds(100*mp3FrameSize*8, mp3Bitrate), SampleRate: 44100, Channels: 2},
+		},
+		{
+			name: "cbr mp3 after ID3 tag",
+			data: mp3File(100, id3Tag(300)),
+			want: domainConspect.AudioInfo{Container: "mp3", Codec: "mp3", Duration: seconds(100*mp3FrameSize*8, mp3Bitrate), SampleRate: 44100, Channels: 2},
+		},
+		{
+			name: "xing mp3",
+			data: xingMP3File(441),
+			want: domainConspect.AudioInfo{Container: "mp3", Codec: "mp3", Duration: seconds(441*1152, 44100), SampleRate: 44100, Channels: 2},
+		},
+		{
+			name: "opus",
+			data: opusFile(5*48000 + 312),
+			want: domainConspect.AudioInfo{Container: "ogg", Codec: "opus", Duration: 5 * time.Second, SampleRate: 48000, Channels: 2},
+		},
+		{
+			name: "vorbis",
+			data: vorbisFile(3 * 22050),
+			want: domainConspect.AudioInfo{Container: "ogg", Codec: "vorbis", Duration: 3 * time.Second, SampleRate: 22050, Channels: 1},
+		},
+		{
+			name: "m4a",
+			data: mp4File(1000, 7500, "soun"),
+			want: domainConspect.AudioInfo{Container: "mp4", Codec: "mp4a", Duration: 7500 * time.Millisecond, SampleRate: 44100, Channels: 2},
+		},
+		{
+			name: "mp4 video track",
+			data: mp4File(600, 1200, "vide"),
+			want: domainConspect.AudioInfo{Container: "mp4", Duration: 2 * time.Second, Video: true},
+		},
+		{
+			name: "webm",
+			data: webmFile(ebmlTrackTypeAudio, "A_OPUS"),
+			want: domainConspect.AudioInfo{Container: "webm", Codec: "opus", Duration: 5 * time.Second, SampleRate: 48000, Channels: 2},
+		},
+		{
+			name: "webm with unknown segment size",
+			data: unknownSizeWebM(),
+			want: domainConspect.AudioInfo{Container: "webm", Codec: "opus", Duration: 5 * time.Second, SampleRate: 48000, Channels: 2},
+		},
+		{
+			name: "webm video track",
+			data: webmFile(ebmlTrackTypeVideo, "V_VP9"),
+			want: domainConspect.AudioInfo{Container: "webm", Duration: 5 * time.Second, Video: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := probeBytes(tt.data)
+			if err != nil {
+				t.Fatalf("Probe() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Probe() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeRejectsMalformedInput(t *testing.T) {
+	oversizedBox := append(mp4Box("ftyp", make([]byte, 8)), "\x00\x00\x00\x01moov"...)
+	oversizedBox = binary.BigEndian.AppendUint64(oversizedBox, math.MaxInt64)
+
+	silentVorbis := vorbisFile(22050)
+	binary.LittleEndian.PutUint32(silentVorbis[oggPageHeaderSize+1+12:], 0)
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, ErrUnknownFormat},
+		{"plain text", []byte("hello, world"), ErrUnknownFormat},
+		{"ID3 tag past the end", []byte("ID30000000"), domainConspect.ErrUndecodableAudio},
+		{"ID3 tag larger than the file", append(id3Tag(0)[:6], 0x7F, 0x7F, 0x7F, 0x7F), domainConspect.ErrUndecodableAudio},
+		{"ID3 tag without frames", id3Tag(20), domainConspect.ErrUndecodableAudio},
+		{"invalid ID3 size", []byte("ID3\x03\x00\x00\x80\x00\x00\x00\xFF\xFB\x90\x00"), domainConspect.ErrUndecodableAudio},
+		{"wav without data", wavFile(0)[:riffHeaderSize+chunkHeaderSize+fmtChunkSize], domainConspect.ErrUndecodableAudio},
+		{"wav data before fmt", append([]byte("RIFF\x00\x00\x00\x00WAVE"), riffChunk("data", make([]byte, 8))...), domainConspect.ErrUndecodableAudio},
+		{"truncated wav fmt", wavFile(16)[:riffHeaderSize+chunkHeaderSize+4], domainConspect.ErrUndecodableAudio},
+		{"truncated ogg page", []byte("OggS\x00\x02"), domainConspect.ErrUndecodableAudio},
+		{"truncated opus head", opusFile(0)[:oggPageHeaderSize+10], domainConspect.ErrUndecodableAudio},
+		{"vorbis with zero sample rate", silentVorbis, domainConspect.ErrUndecodableAudio},
+		{"mp4 without moov", mp4Box("ftyp", make([]byte, 8)), domainConspect.ErrUndecodableAudio},
+		{"mp4 with truncated moov", mp4File(1000, 7500, "soun")[:40], domainConspect.ErrUndecodableAudio},
+		{"mp4 with overflowing large box", oversizedBox, domainConspect.ErrUndecodableAudio},
+		{"webm without segment", ebml(ebmlIDHeader, ebml(ebmlIDDocType, []byte("webm"))), domainConspect.ErrUndecodableAudio},
+		{"truncated webm", webmFile(ebmlTrackTypeAudio, "A_OPUS")[:30], domainConspect.ErrUndecodableAudio},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := probeBytes(tt.data); !errors.Is(err, tt.want) {
+				t.Fatalf("Probe() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func FuzzProbe(f *testing.F) {
+	f.Add([]byte("ID30000000"))
+	f.Add(wavFile(64))
+	f.Add(mp3File(4, id3Tag(16)))
+	f.Add(xingMP3File(10))
+	f.Add(opusFile(48000))
+	f.Add(vorbisFile(22050))
+	f.Add(mp4File(1000, 7500, "soun"))
+	f.Add(webmFile(ebmlTrackTypeAudio, "A_OPUS"))
+	f.Add(unknownSizeWebM())
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		info, err := probeBytes(data)
+		if err != nil {
+			if !errors.Is(err, ErrUnknownFormat) && !errors.Is(err, domainConspect.ErrUndecodableAudio) {
+				t.Fatalf("Probe() returned an unclassified error: %v", err)
+			}
+			return
+		}
+		if info.Container == "" {
+			t.Fatalf("Probe() = %+v without a container", info)
+		}
+	})
+}
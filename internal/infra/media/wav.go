@@ -0,0 +1,56 @@
+package media
+
+import (
+	"encoding/binary"
+	"io"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+const (
+	riffHeaderSize  = 12
+	chunkHeaderSize = 8
+	fmtChunkSize    = 16
+)
+
+func probeWAV(r io.ReaderAt, size int64) (domainConspect.AudioInfo, error) {
+	info := domainConspect.AudioInfo{Container: "wav", Codec: "pcm"}
+
+	var byteRate uint32
+	offset := int64(riffHeaderSize)
+	for offset+chunkHeaderSize <= size {
+		chunk, err := readAt(r, offset, chunkHeaderSize)
+		if err != nil {
+			return info, corrupt("wav: %v", err)
+		}
+		id, chunkSize := string(chunk[:4]), int64(le32(chunk[4:]))
+		body := offset + chunkHeaderSize
+
+		switch id {
+		case "fmt ":
+			format, err := readAt(r, body, fmtChunkSize)
+			if err != nil {
+				return info, corrupt("wav: truncated fmt chunk")
+			}
+			if binary.LittleEndian.Uint16(format) != 1 {
+				info.Codec = "wav"
+			}
+			info.Channels = int(binary.LittleEndian.Uint16(format[2:]))
+			info.SampleRate = int(le32(format[4:]))
+			byteRate = le32(format[8:])
+		case "data":
+			if byteRate == 0 {
+				return info, corrupt("wav: data chunk before a valid fmt chunk")
+			}
+			if chunkSize == 0xFFFFFFFF || body+chunkSize > size {
+				chunkSize = size - body
+			}
+			info.Duration = seconds(float64(chunkSize), float64(byteRate))
+			return info, nil
+		}
+
+		offset = body + chunkSize + chunkSize%2
+	}
+
+	return info, corrupt("wav: missing data chunk")
+}
@@ -0,0 +1,290 @@
+package media
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"strings"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+const (
+	ebmlIDHeader          = 0x1A45DFA3
+	ebmlIDDocType         = 0x4282
+	ebmlIDSegment         = 0x18538067
+	ebmlIDInfo            = 0x1549A966
+	ebmlIDTimecodeScale   = 0x2AD7B1
+	ebmlIDDuration        = 0x4489
+	ebmlIDTracks          = 0x1654AE6B
+	ebmlIDTrackEntry      = 0xAE
+	ebmlIDTrackType       = 0x83
+	ebmlIDCodecID         = 0x86
+	ebmlIDAudio           = 0xE1
+	ebmlIDSamplingRate    = 0xB5
+	ebmlIDChannels        = 0x9F
+	ebmlIDCluster         = 0x1F43B675
+	ebmlTrackTypeVideo    = 1
+	ebmlTrackTypeAudio    = 2
+	ebmlDefaultTimescale  = 1000000
+	ebmlMaxElementPayload = 1024 * 1024
+	ebmlUnknownSize       = -1
+)
+
+var ebmlMagic = []byte{0x1A, 0x45, 0xDF, 0xA3}
+
+type ebmlElement struct {
+	id     uint32
+	offset int64
+	size   int64
+}
+
+type webmProbe struct {
+	r         io.ReaderAt
+	info      domainConspect.AudioInfo
+	timescale uint64
+	duration  float64
+	hasInfo   bool
+	hasTracks bool
+}
+
+func probeWebM(r io.ReaderAt, size int64) (domainConspect.AudioInfo, error) {
+	p := &webmProbe{
+		r:         r,
+		info:      domainConspect.AudioInfo{Container: "webm"},
+		timescale: ebmlDefaultTimescale,
+	}
+
+	header, err := readElement(r, 0, size)
+	if err != nil || header.id != ebmlIDHeader {
+		return p.info, corrupt("webm: invalid EBML header")
+	}
+	if err := p.walk(header.offset, header.end(size), p.visitHeader); err != nil {
+		return p.info, err
+	}
+
+	segment, err := readElement(r, header.end(size), size)
+	if err != nil || segment.id != ebmlIDSegment {
+		return p.info, corrupt("webm: missing segment")
+	}
+	if err := p.walk(segment.offset, segment.end(size), p.visitSegment); err != nil {
+		return p.info, err
+	}
+	if !p.hasInfo {
+		return p.info, corrupt("webm: missing segment info")
+	}
+
+	p.info.Duration = seconds(p.duration*float64(p.timescale), 1e9)
+	return p.info, nil
+}
+
+func (p *webmProbe) visitHeader(element ebmlElement) (bool, error) {
+	if element.id == ebmlIDDocType {
+		value, err := p.payload(element)
+		if err != nil {
+			return false, err
+		}
+		if docType := strings.TrimRight(string(value), "\x00"); docType != "webm" {
+			p.info.Container = docType
+		}
+	}
+	return false, nil
+}
+
+func (p *webmProbe) visitSegment(element ebmlElement) (bool, error) {
+	switch element.id {
+	case ebmlIDInfo:
+		p.hasInfo = true
+		return false, p.walk(element.offset, element.offset+element.size, p.visitInfo)
+	case ebmlIDTracks:
+		p.hasTracks = true
+		return false, p.walk(element.offset, element.offset+element.size, p.visitTracks)
+	case ebmlIDCluster:
+		return true, nil
+	}
+	return p.hasInfo && p.hasTracks, nil
+}
+
+func (p *webmProbe) visitInfo(element ebmlElement) (bool, error) {
+	switch element.id {
+	case ebmlIDTimecodeScale:
+		value, err := p.payload(element)
+		if err != nil {
+			return false, err
+		}
+		if scale := readUint(value); scale > 0 {
+			p.timescale = scale
+		}
+	case ebmlIDDuration:
+		value, err := p.payload(element)
+		if err != nil {
+			return false, err
+		}
+		p.duration = readFloat(value)
+	}
+	return false, nil
+}
+
+func (p *webmProbe) visitTracks(element ebmlElement) (bool, error) {
+	if element.id != ebmlIDTrackEntry {
+		return false, nil
+	}
+
+	var (
+		trackType uint64
+		codec     string
+		details   ebmlElement
+	)
+	err := p.walk(element.offset, element.offset+element.size, func(child ebmlElement) (bool, error) {
+		switch child.id {
+		case ebmlIDTrackType:
+			value, err := p.payload(child)
+			if err != nil {
+				return false, err
+			}
+			trackType = readUint(value)
+		case ebmlIDCodecID:
+			value, err := p.payload(child)
+			if err != nil {
+				return false, err
+			}
+			codec = strings.ToLower(strings.TrimPrefix(strings.TrimRight(string(value), "\x00"), "A_"))
+		case ebmlIDAudio:
+			details = child
+		}
+		return false, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if trackType == ebmlTrackTypeVideo {
+		p.info.Video = true
+	}
+	if trackType != ebmlTrackTypeAudio || p.info.Codec != "" {
+		return false, nil
+	}
+
+	p.info.Codec = codec
+	if details.size > 0 {
+		return false, p.walk(details.offset, details.offset+details.size, p.visitAudio)
+	}
+	return false, nil
+}
+
+func (p *webmProbe) visitAudio(element ebmlElement) (bool, error) {
+	switch element.id {
+	case ebmlIDSamplingRate:
+		value, err := p.payload(element)
+		if err != nil {
+			return false, err
+		}
+		p.info.SampleRate = int(readFloat(value))
+	case ebmlIDChannels:
+		value, err := p.payload(element)
+		if err != nil {
+			return false, err
+		}
+		p.info.Channels = int(readUint(value))
+	}
+	return false, nil
+}
+
+func (p *webmProbe) walk(start, end int64, visit func(ebmlElement) (bool, error)) error {
+	for offset := start; offset < end; {
+		element, err := readElement(p.r, offset, end)
+		if err != nil {
+			return err
+		}
+
+		stop, err := visit(element)
+		if err != nil || stop || element.size == ebmlUnknownSize {
+			return err
+		}
+		offset = element.offset + element.size
+	}
+	return nil
+}
+
+func (p *webmProbe) payload(element ebmlElement) ([]byte, error) {
+	if element.size < 0 || element.size > ebmlMaxElementPayload {
+		return nil, corrupt("webm: element %#x has an invalid size", element.id)
+	}
+	value, err := readAt(p.r, element.offset, int(element.size))
+	if err != nil {
+		return nil, corrupt("webm: truncated element %#x", element.id)
+	}
+	return value, nil
+}
+
+func (e ebmlElement) end(limit int64) int64 {
+	if e.size == ebmlUnknownSize {
+		return limit
+	}
+	return e.offset + e.size
+}
+
+func readElement(r io.ReaderAt, offset, limit int64) (ebmlElement, error) {
+	id, idLength, err := readVint(r, offset, false)
+	if err != nil {
+		return ebmlElement{}, err
+	}
+	size, sizeLength, err := readVint(r, offset+int64(idLength), true)
+	if err != nil {
+		return ebmlElement{}, err
+	}
+
+	element := ebmlElement{id: uint32(id), offset: offset + int64(idLength+sizeLength), size: int64(size)}
+	if size == 1<<(7*sizeLength)-1 {
+		element.size = ebmlUnknownSize
+	} else if element.offset+element.size > limit {
+		return element, corrupt("webm: element %#x overflows its parent", element.id)
+	}
+	return element, nil
+}
+
+func readVint(r io.ReaderAt, offset int64, stripMarker bool) (uint64, int, error) {
+	first, err := readAt(r, offset, 1)
+	if err != nil {
+		return 0, 0, corrupt("webm: %v", err)
+	}
+
+	length := 1
+	for mask := byte(0x80); length <= 8 && first[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 8 {
+		return 0, 0, corrupt("webm: invalid variable-length integer")
+	}
+
+	raw, err := readAt(r, offset, length)
+	if err != nil {
+		return 0, 0, corrupt("webm: %v", err)
+	}
+
+	value := uint64(raw[0])
+	if stripMarker {
+		value &= uint64(0xFF >> length)
+	}
+	for _, b := range raw[1:] {
+		value = value<<8 | uint64(b)
+	}
+	return value, length, nil
+}
+
+func readUint(value []byte) uint64 {
+	var result uint64
+	for _, b := range value {
+		result = result<<8 | uint64(b)
+	}
+	return result
+}
+
+func readFloat(value []byte) float64 {
+	switch len(value) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(value)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(value))
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package prompts
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
+)
+
+//go:embed styles/*.json styles/*.tmpl
+var stylesFS embed.FS
+
+type manifest struct {
+	Name        string      `json:"name"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Layout      string      `json:"layout"`
+	Template    string      `json:"template"`
+	Parameters  []parameter `json:"parameters"`
+}
+
+type parameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     string `json:"default"`
+	Required    bool   `json:"required"`
+}
+
+func Builtin() ([]*style.Style, error) {
+	sub, err := fs.Sub(stylesFS, "styles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open builtin styles: %w", err)
+	}
+	return Load(sub)
+}
+
+func Load(fsys fs.FS) ([]*style.Style, error) {
+	manifests, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list style manifests: %w", err)
+	}
+	sort.Strings(manifests)
+
+	styles := make([]*style.Style, 0, len(manifests))
+	for _, name := range manifests {
+		st, err := loadStyle(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load style %s: %w", name, err)
+		}
+		styles = append(styles, st)
+	}
+	return styles, nil
+}
+
+func loadStyle(fsys fs.FS, manifestPath string) (*style.Style, error) {
+	content, err := fs.ReadFile(fsys, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var m manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	tmpl, err := fs.ReadFile(fsys, path.Join(path.Dir(manifestPath), m.Template))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	layout, err := style.ParseLayout(m.Layout)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]style.Parameter, 0, len(m.Parameters))
+	for _, p := range m.Parameters {
+		params = append(params, style.Parameter{
+			Name:        p.Name,
+			Description: p.Description,
+			Default:     p.Default,
+			Required:    p.Required,
+		})
+	}
+
+	st, err := style.NewStyle(m.Name, m.Title, m.Description, layout, params, string(tmpl))
+	if err != nil {
+		return nil, err
+	}
+	st.Builtin = true
+	return st, nil
+}
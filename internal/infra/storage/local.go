@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/domain/artifact"
+)
+
+const (
+	filePermissions = 0o600
+	dirPermissions  = 0o700
+
+	queryExpires   = "expires"
+	queryFilename  = "filename"
+	querySignature = "signature"
+)
+
+type LocalStorage struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+}
+
+func NewLocalStorage(dir, baseURL string, signingKey []byte) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStorage{
+		dir:        dir,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		signingKey: signingKey,
+	}, nil
+}
+
+func (s *LocalStorage) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), dirPermissions); err != nil {
+		return fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), ".put-*")
+	if err != nil {
+		return fmt.Errorf("failed to create object file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	if _, err := io.Copy(tmpFile, r); err != nil {
+		return fmt.Errorf("failed to write object: %w", err)
+	}
+	if err := tmpFile.Chmod(filePermissions); err != nil {
+		return fmt.Errorf("failed to set object permissions: %w", err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		return fmt.Errorf("failed to sync object: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to store object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, artifact.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object: %w", err)
+	}
+	return file, nil
+}
+
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) SignedURL(_ context.Context, key, filename string, ttl time.Duration) (string, error) {
+	if err := artifact.ValidateKey(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{}
+	query.Set(queryExpires, expires)
+	query.Set(queryFilename, filename)
+	query.Set(querySignature, s.sign(key, expires, filename))
+
+	return s.baseURL + c.FilesPath + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+func (s *LocalStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, c.FilesPath+"/")
+	query := r.URL.Query()
+	expires, filename, signature := query.Get(queryExpires), query.Get(queryFilename), query.Get(querySignature)
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt || !hmac.Equal([]byte(signature), []byte(s.sign(key, expires, filename))) {
+		apperror.Write(w, r, apperror.New(apperror.CodeNotFound, "File link is invalid or expired"))
+		return
+	}
+
+	path, err := s.path(key)
+	if err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeNotFound, "File not found", err))
+		return
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeNotFound, "File not found", err))
+		return
+	}
+	if err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInternal, "Failed to open file", err))
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		apperror.Write(w, r, apperror.Wrap(apperror.CodeInternal, "Failed to read file", err))
+		return
+	}
+
+	if filename != "" {
+		w.Header().Set(c.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	http.ServeContent(w, r, filename, info.ModTime(), file)
+}
+
+func (s *LocalStorage) sign(key, expires, filename string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires + "\n" + filename))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	if err := artifact.ValidateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
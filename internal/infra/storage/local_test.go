@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+func newTestLocalStorage(t *testing.T) *LocalStorage {
+	t.Helper()
+	s, err := NewLocalStorage(t.TempDir(), "http://localhost", []byte("secret"))
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	return s
+}
+
+func serveSigned(t *testing.T, s *LocalStorage, signedURL string) *httptest.ResponseRecorder {
+	t.Helper()
+	parsed, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("parse signed URL: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, parsed.RequestURI(), nil))
+	return rec
+}
+
+func TestLocalStorageServesSignedURL(t *testing.T) {
+	s := newTestLocalStorage(t)
+	ctx := context.Background()
+	if err := s.Put(ctx, "conspects/1/notes.pdf", strings.NewReader("pdf"), 3, "application/pdf"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	signedURL, err := s.SignedURL(ctx, "conspects/1/notes.pdf", "notes.pdf", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	rec := serveSigned(t, s, signedURL)
+	if rec.Code != http.StatusOK || rec.Body.String() != "pdf" {
+		t.Fatalf("response = %d %q, want 200 %q", rec.Code, rec.Body.String(), "pdf")
+	}
+	if disposition := rec.Header().Get(c.HeaderContentDisposition); !strings.Contains(disposition, "notes.pdf") {
+		t.Fatalf("Content-Disposition = %q", disposition)
+	}
+}
+
+func TestLocalStorageWritesProblemErrors(t *testing.T) {
+	s := newTestLocalStorage(t)
+	ctx := context.Background()
+	if err := s.Put(ctx, "conspects/1/notes.pdf", strings.NewReader("pdf"), 3, ""); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	valid, _ := s.SignedURL(ctx, "conspects/1/notes.pdf", "", time.Minute)
+	expired, _ := s.SignedURL(ctx, "conspects/1/notes.pdf", "", -time.Minute)
+	missing, _ := s.SignedURL(ctx, "conspects/9/missing.pdf", "", time.Minute)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantCode   string
+	}{
+		{"tampered signature", strings.Replace(valid, "signature=", "signature=0", 1), http.StatusNotFound, "not_found"},
+		{"expired link", expired, http.StatusNotFound, "not_found"},
+		{"missing object", missing, http.StatusNotFound, "not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveSigned(t, s, tt.url)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if contentType := rec.Header().Get(c.HeaderContentType); contentType != c.ContentTypeProblemJSON {
+				t.Fatalf("Content-Type = %q, want %q", contentType, c.ContentTypeProblemJSON)
+			}
+			var problem struct {
+				Code string `json:"code"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil || problem.Code != tt.wantCode {
+				t.Fatalf("problem code = %q, %v, want %q", problem.Code, err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/domain/artifact"
+)
+
+const s3TestEndpointEnv = "S3_TEST_ENDPOINT"
+
+func newIntegrationStorage(t *testing.T) *S3Storage {
+	t.Helper()
+	endpoint := os.Getenv(s3TestEndpointEnv)
+	if endpoint == "" {
+		t.Skipf("set %s to run against MinIO or another S3-compatible server", s3TestEndpointEnv)
+	}
+
+	cfg := S3Config{
+		Endpoint:        endpoint,
+		Region:          envOr("S3_TEST_REGION", "us-east-1"),
+		Bucket:          envOr("S3_TEST_BUCKET", "conspects-test"),
+		AccessKeyID:     envOr("S3_TEST_ACCESS_KEY_ID", "minioadmin"),
+		SecretAccessKey: envOr("S3_TEST_SECRET_ACCESS_KEY", "minioadmin"),
+		PathStyle:       true,
+	}
+	s, err := NewS3Storage(cfg, 30*time.Second)
+	if err != nil {
+		t.Fatalf("NewS3Storage() error = %v", err)
+	}
+	ensureBucket(t, s)
+	return s
+}
+
+func ensureBucket(t *testing.T, s *S3Storage) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPut, s.endpoint.Scheme+"://"+s.endpoint.Host+"/"+s.bucket, nil)
+	if err != nil {
+		t.Fatalf("create bucket request: %v", err)
+	}
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		t.Fatalf("create bucket: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		t.Fatalf("create bucket returned %d", resp.StatusCode)
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func TestS3StorageRoundTrip(t *testing.T) {
+	s := newIntegrationStorage(t)
+	ctx := context.Background()
+	key := "integration/" + strconv.FormatInt(time.Now().UnixNano(), 10) + "/конспект notes.txt"
+	content := "Recursion is a function calling itself."
+
+	if err := s.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	t.Cleanup(func() { s.Delete(context.Background(), key) })
+
+	body, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	got, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || string(got) != content {
+		t.Fatalf("Get() content = %q, %v", got, err)
+	}
+
+	signedURL, err := s.SignedURL(ctx, key, "notes.txt", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	resp, err := http.Get(signedURL)
+	if err != nil {
+		t.Fatalf("GET signed URL: %v", err)
+	}
+	downloaded, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(downloaded) != content {
+		t.Fatalf("signed URL = %d %q, want 200 %q", resp.StatusCode, downloaded, content)
+	}
+	if disposition := resp.Header.Get("Content-Disposition"); !strings.Contains(disposition, "notes.txt") {
+		t.Fatalf("Content-Disposition = %q, want the download filename", disposition)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, artifact.ErrObjectNotFound) {
+		t.Fatalf("Get() after delete error = %v, want %v", err, artifact.ErrObjectNotFound)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() of a missing object error = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package uploads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	domainUpload "github.com/goIdioms/conspect-generator/internal/domain/upload"
+)
+
+const filePermissions = 0o600
+
+type DiskStore struct {
+	dir string
+}
+
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	return &DiskStore{dir: dir}, nil
+}
+
+func (s *DiskStore) Create(_ context.Context, id string) error {
+	file, err := os.OpenFile(s.path(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, filePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to create upload file: %w", err)
+	}
+	return file.Close()
+}
+
+func (s *DiskStore) Append(_ context.Context, id string, offset int64, r io.Reader) (int64, error) {
+	file, err := os.OpenFile(s.path(id), os.O_WRONLY, filePermissions)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, domainUpload.ErrUploadNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	defer file.Close()
+
+	if err := file.Truncate(offset); err != nil {
+		return 0, fmt.Errorf("failed to truncate upload file: %w", err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek upload file: %w", err)
+	}
+
+	written, err := io.Copy(file, r)
+	if err != nil {
+		return written, fmt.Errorf("failed to write chunk: %w", err)
+	}
+	return written, file.Sync()
+}
+
+func (s *DiskStore) Truncate(_ context.Context, id string, size int64) error {
+	if err := os.Truncate(s.path(id), size); err != nil {
+		return fmt.Errorf("failed to truncate upload file: %w", err)
+	}
+	return nil
+}
+
+func (s *DiskStore) Open(_ context.Context, id string) (domainUpload.File, error) {
+	file, err := os.Open(s.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domainUpload.ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open upload file: %w", err)
+	}
+	return file, nil
+}
+
+func (s *DiskStore) Delete(_ context.Context, id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete upload file: %w", err)
+	}
+	return nil
+}
+
+func (s *DiskStore) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
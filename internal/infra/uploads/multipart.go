@@ -0,0 +1,152 @@
+package uploads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const sniffLen = 512
+
+var (
+	ErrFileTooLarge  = errors.New("file exceeds the maximum size")
+	ErrValueTooLarge = errors.New("form value exceeds the maximum size")
+	ErrDuplicateFile = errors.New("form contains more than one file")
+)
+
+type SizeLimit func(filename string) int64
+
+func FixedSize(size int64) SizeLimit {
+	return func(string) int64 { return size }
+}
+
+type ReceivedFile struct {
+	*os.File
+	Header      *multipart.FileHeader
+	ContentType string
+	SHA256      string
+}
+
+type Form struct {
+	Values url.Values
+	File   *ReceivedFile
+}
+
+func (f *Form) Value(key string) string {
+	return f.Values.Get(key)
+}
+
+func (f *Form) Close() error {
+	if f.File == nil {
+		return nil
+	}
+	f.File.Close()
+	return os.Remove(f.File.Name())
+}
+
+type sniffer struct {
+	buf []byte
+}
+
+func (s *sniffer) Write(p []byte) (int, error) {
+	if remaining := sniffLen - len(s.buf); remaining > 0 {
+		s.buf = append(s.buf, p[:min(remaining, len(p))]...)
+	}
+	return len(p), nil
+}
+
+func ReceiveForm(r *multipart.Reader, fileField, pattern string, maxFileSize SizeLimit, maxValueSize int64) (*Form, error) {
+	form := &Form{Values: url.Values{}}
+
+	for {
+		part, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			return form, nil
+		}
+		if err != nil {
+			form.Close()
+			return nil, fmt.Errorf("failed to read multipart part: %w", err)
+		}
+
+		err = form.receivePart(part, fileField, pattern, maxFileSize, maxValueSize)
+		part.Close()
+		if err != nil {
+			form.Close()
+			return nil, err
+		}
+	}
+}
+
+func (f *Form) receivePart(part *multipart.Part, fileField, pattern string, maxFileSize SizeLimit, maxValueSize int64) error {
+	name := part.FormName()
+	if name == "" {
+		return nil
+	}
+
+	if part.FileName() == "" {
+		value, err := io.ReadAll(io.LimitReader(part, maxValueSize+1))
+		if err != nil {
+			return fmt.Errorf("failed to read form value: %w", err)
+		}
+		if int64(len(value)) > maxValueSize {
+			return fmt.Errorf("%w: %s", ErrValueTooLarge, name)
+		}
+		f.Values.Add(name, string(value))
+		return nil
+	}
+
+	if name != fileField {
+		return nil
+	}
+	if f.File != nil {
+		return ErrDuplicateFile
+	}
+
+	file, err := ReceiveFile(part, &multipart.FileHeader{Filename: part.FileName(), Header: part.Header}, pattern, maxFileSize(part.FileName()))
+	if err != nil {
+		return err
+	}
+	f.File = file
+	return nil
+}
+
+func ReceiveFile(r io.Reader, header *multipart.FileHeader, pattern string, maxFileSize int64) (*ReceivedFile, error) {
+	tmpFile, err := os.CreateTemp("", pattern+strings.ToLower(filepath.Ext(header.Filename)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	hasher := sha256.New()
+	sniff := &sniffer{buf: make([]byte, 0, sniffLen)}
+	size, err := io.Copy(io.MultiWriter(tmpFile, hasher, sniff), io.LimitReader(r, maxFileSize+1))
+	if err == nil && size > maxFileSize {
+		err = ErrFileTooLarge
+	}
+	if err == nil {
+		_, err = tmpFile.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		if errors.Is(err, ErrFileTooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save uploaded file: %w", err)
+	}
+
+	header.Size = size
+	return &ReceivedFile{
+		File:        tmpFile,
+		Header:      header,
+		ContentType: http.DetectContentType(sniff.buf),
+		SHA256:      hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
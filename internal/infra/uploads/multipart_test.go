@@ -0,0 +1,89 @@
+package uploads
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func multipartBody(t testing.TB, filename string, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("pages", "3"); err != nil {
+		t.Fatalf("write field: %v", err)
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close writer: %v", err)
+	}
+	return &body, writer.Boundary()
+}
+
+func TestReceiveFormLimitsFileByName(t *testing.T) {
+	limit := func(filename string) int64 {
+		if strings.EqualFold(filepath.Ext(filename), ".mp4") {
+			return 64
+		}
+		return 16
+	}
+
+	tests := []struct {
+		name     string
+		filename string
+		size     int
+		wantErr  error
+	}{
+		{"audio within limit", "lecture.mp3", 16, nil},
+		{"audio over limit", "lecture.mp3", 17, ErrFileTooLarge},
+		{"video within limit", "lecture.MP4", 64, nil},
+		{"video over limit", "lecture.mp4", 65, ErrFileTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, boundary := multipartBody(t, tt.filename, bytes.Repeat([]byte{'a'}, tt.size))
+			form, err := ReceiveForm(multipart.NewReader(body, boundary), "file", "upload-test-*", limit, 16)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReceiveForm() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer form.Close()
+
+			if form.File.Header.Size != int64(tt.size) {
+				t.Fatalf("file size = %d, want %d", form.File.Header.Size, tt.size)
+			}
+			if got := form.Value("pages"); got != "3" {
+				t.Fatalf("pages = %q, want %q", got, "3")
+			}
+		})
+	}
+}
+
+func BenchmarkReceiveForm(b *testing.B) {
+	const uploadSize = 100 << 20
+
+	body, boundary := multipartBody(b, "lecture.mp3", bytes.Repeat([]byte{0xff, 0xfb, 0x90, 0x64}, uploadSize/4))
+	payload := body.Bytes()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	for b.Loop() {
+		form, err := ReceiveForm(multipart.NewReader(bytes.NewReader(payload), boundary), "file", "upload-bench-*", FixedSize(uploadSize), 16)
+		if err != nil {
+			b.Fatalf("ReceiveForm() error = %v", err)
+		}
+		form.Close()
+	}
+}
@@ -0,0 +1,125 @@
+package video
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	workDirPattern = "slides-*"
+	framePattern   = "slide-%03d.jpg"
+	frameGlob      = "slide-*.jpg"
+	frameWidth     = 1280
+	jpegQuality    = "4"
+	maxErrorOutput = 512
+)
+
+var (
+	tracer = tracing.Tracer("github.com/goIdioms/conspect-generator/internal/infra/video")
+
+	ptsTimePattern = regexp.MustCompile(`pts_time:\s*(-?[0-9.]+)`)
+)
+
+type FFmpegSlideExtractor struct {
+	ffmpegPath string
+	threshold  float64
+	maxSlides  int
+	timeout    time.Duration
+}
+
+func NewFFmpegSlideExtractor(ffmpegPath string, threshold float64, maxSlides int, timeout time.Duration) *FFmpegSlideExtractor {
+	return &FFmpegSlideExtractor{
+		ffmpegPath: ffmpegPath,
+		threshold:  threshold,
+		maxSlides:  maxSlides,
+		timeout:    timeout,
+	}
+}
+
+func (e *FFmpegSlideExtractor) ExtractSlides(ctx context.Context, filePath string) ([]domainConspect.Slide, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	ctx, span := tracer.Start(ctx, "ffmpeg.slides")
+	defer span.End()
+
+	dir, err := os.MkdirTemp("", workDirPattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create slides directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	filter := fmt.Sprintf("select='eq(n,0)+gt(scene,%s)',showinfo,scale='min(%d,iw)':-2",
+		strconv.FormatFloat(e.threshold, 'f', 2, 64), frameWidth)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.ffmpegPath,
+		"-hide_banner", "-nostdin",
+		"-skip_frame", "nokey",
+		"-i", filePath,
+		"-map", "0:v:0",
+		"-vf", filter,
+		"-fps_mode", "vfr",
+		"-frames:v", strconv.Itoa(e.maxSlides),
+		"-q:v", jpegQuality,
+		filepath.Join(dir, framePattern),
+	)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("ffmpeg was interrupted: %w", ctx.Err())
+		} else {
+			err = fmt.Errorf("failed to extract slides: %w: %s", err, tail(stderr.String()))
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	frames, err := filepath.Glob(filepath.Join(dir, frameGlob))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list slides: %w", err)
+	}
+	sort.Strings(frames)
+
+	timestamps := ptsTimePattern.FindAllStringSubmatch(stderr.String(), -1)
+	slides := make([]domainConspect.Slide, 0, len(frames))
+	for i, frame := range frames {
+		image, err := os.ReadFile(frame)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read slide: %w", err)
+		}
+
+		slide := domainConspect.Slide{Image: image}
+		if i < len(timestamps) {
+			if seconds, err := strconv.ParseFloat(timestamps[i][1], 64); err == nil && seconds > 0 {
+				slide.At = time.Duration(seconds * float64(time.Second))
+			}
+		}
+		slides = append(slides, slide)
+	}
+	span.SetAttributes(attribute.Int("video.slides", len(slides)))
+
+	return slides, nil
+}
+
+func tail(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxErrorOutput {
+		output = "..." + output[len(output)-maxErrorOutput:]
+	}
+	return output
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FieldRequestID = "request_id"
+	FieldUserID    = "user_id"
+	FieldRoute     = "route"
+	FieldJobID     = "job_id"
+)
+
+type contextKey struct{}
+
+type scope struct {
+	mu    sync.RWMutex
+	entry *logrus.Entry
+}
+
+var defaultLogger = logrus.New()
+
+func New() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.AddHook(RouteHook{})
+	logger.AddHook(RedactHook{})
+
+	if level, err := logrus.ParseLevel(os.Getenv("LOG_LEVEL")); err == nil {
+		logger.SetLevel(level)
+	}
+
+	defaultLogger = logger
+	return logger
+}
+
+func FromContext(ctx context.Context) *logrus.Entry {
+	sc, ok := ctx.Value(contextKey{}).(*scope)
+	if !ok {
+		return logrus.NewEntry(defaultLogger).WithContext(ctx)
+	}
+
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.entry.WithContext(ctx)
+}
+
+func WithEntry(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, &scope{entry: entry})
+}
+
+func AddField(ctx context.Context, key string, value any) {
+	sc, ok := ctx.Value(contextKey{}).(*scope)
+	if !ok {
+		return
+	}
+
+	sc.mu.Lock()
+	sc.entry = sc.entry.WithField(key, value)
+	sc.mu.Unlock()
+}
+
+type RouteHook struct{}
+
+func (RouteHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (RouteHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	if rctx := chi.RouteContext(entry.Context); rctx != nil && rctx.RoutePattern() != "" {
+		entry.Data[FieldRoute] = rctx.RoutePattern()
+	}
+	return nil
+}
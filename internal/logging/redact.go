@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	emailPattern = regexp.MustCompile(`([a-zA-Z0-9._%+-])[a-zA-Z0-9._%+-]*@([a-zA-Z0-9.-]+\.[a-zA-Z]{2,})`)
+
+	sensitiveKeyParts = []string{"token", "cookie", "authorization", "password", "secret"}
+	sensitiveKeys     = map[string]bool{"code": true, "state": true, "oauth_state": true}
+)
+
+type RedactHook struct{}
+
+func (RedactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (RedactHook) Fire(entry *logrus.Entry) error {
+	for key, value := range entry.Data {
+		if isSensitiveKey(key) {
+			entry.Data[key] = redacted
+			continue
+		}
+		if s, ok := value.(string); ok {
+			entry.Data[key] = RedactString(s)
+		}
+	}
+	entry.Message = RedactString(entry.Message)
+	return nil
+}
+
+func RedactString(s string) string {
+	return emailPattern.ReplaceAllString(s, "$1***@$2")
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if sensitiveKeys[key] {
+		return true
+	}
+	for _, sensitive := range sensitiveKeyParts {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "conspect"
+
+const (
+	StageTranscribe = "transcribe"
+	StageSummarize  = "summarize"
+	StageRender     = "render"
+	StageDiarize    = "diarize"
+	StagePreprocess = "preprocess"
+	StageSlides     = "slides"
+	StageExtract    = "extract"
+
+	TokenTypePrompt     = "prompt"
+	TokenTypeCompletion = "completion"
+
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+type ActiveSessionsFunc func(ctx context.Context) (int64, error)
+
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	HTTPRequestDuration   *prometheus.HistogramVec
+	StageDuration         *prometheus.HistogramVec
+	AudioSecondsProcessed prometheus.Counter
+	TokensConsumed        *prometheus.CounterVec
+	PDFPages              prometheus.Histogram
+	RateLimitRejections   prometheus.Counter
+	ContractViolations    *prometheus.CounterVec
+	CacheLookups          *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	m := &Metrics{
+		Registry: registry,
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by chi route pattern.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+		}, []string{"method", "route", "status"}),
+		StageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "conversion_stage_duration_seconds",
+			Help:      "Duration of each conversion pipeline stage.",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+		}, []string{"stage"}),
+		AudioSecondsProcessed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "audio_seconds_processed_total",
+			Help:      "Total seconds of audio transcribed.",
+		}),
+		TokensConsumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "llm_tokens_consumed_total",
+			Help:      "Tokens consumed by summarization requests.",
+		}, []string{"model", "type"}),
+		PDFPages: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "pdf_pages",
+			Help:      "Page count of rendered PDF documents.",
+			Buckets:   []float64{1, 2, 3, 5, 10, 20, 30, 50},
+		}),
+		RateLimitRejections: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rate_limit_rejections_total",
+			Help:      "Requests rejected by the rate limiter.",
+		}),
+		ContractViolations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "style_contract_violations_total",
+			Help:      "Generated summaries rejected by the style contract validator.",
+		}, []string{"violation"}),
+		CacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Transcript and summary cache lookups by stage and result.",
+		}, []string{"stage", "result"}),
+	}
+
+	registry.MustRegister(
+		m.HTTPRequestDuration,
+		m.StageDuration,
+		m.AudioSecondsProcessed,
+		m.TokensConsumed,
+		m.PDFPages,
+		m.RateLimitRejections,
+		m.ContractViolations,
+		m.CacheLookups,
+	)
+
+	return m
+}
+
+func (m *Metrics) RegisterDB(db *sql.DB, activeSessions ActiveSessionsFunc) {
+	m.Registry.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
+	m.Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_sessions",
+		Help:      "Number of unexpired user sessions.",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		count, err := activeSessions(ctx)
+		if err != nil {
+			return 0
+		}
+		return float64(count)
+	}))
+}
+
+func (m *Metrics) ObserveStage(stage string, start time.Time) {
+	m.StageDuration.WithLabelValues(stage).Observe(time.Since(start).Seconds())
+}
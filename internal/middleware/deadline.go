@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+func Deadlines(read, write time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rc := http.NewResponseController(w)
+			rc.SetReadDeadline(deadline(read))
+			rc.SetWriteDeadline(deadline(write))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func deadline(timeout time.Duration) time.Time {
+	if timeout <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(timeout)
+}
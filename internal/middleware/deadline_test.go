@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeadlinesBoundRequestBodyReads(t *testing.T) {
+	short := Deadlines(50*time.Millisecond, time.Second)
+	long := Deadlines(2*time.Second, 2*time.Second)
+
+	tests := []struct {
+		name    string
+		handler func(http.Handler) http.Handler
+		wantErr bool
+	}{
+		{"default deadline cuts a stalled body", short, true},
+		{"route deadline extends the default", func(next http.Handler) http.Handler { return short(long(next)) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readErr := make(chan error, 1)
+			srv := httptest.NewServer(tt.handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, err := io.ReadAll(r.Body)
+				readErr <- err
+			})))
+			defer srv.Close()
+
+			body, writer := io.Pipe()
+			go func() {
+				writer.Write([]byte("first chunk"))
+				time.Sleep(200 * time.Millisecond)
+				writer.Write([]byte("second chunk"))
+				writer.Close()
+			}()
+
+			req, err := http.NewRequest(http.MethodPost, srv.URL, body)
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+			go func() {
+				if resp, err := http.DefaultClient.Do(req); err == nil {
+					resp.Body.Close()
+				}
+			}()
+
+			select {
+			case err := <-readErr:
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("reading body error = %v, want error %t", err, tt.wantErr)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("handler did not finish reading the body")
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/i18n"
+)
+
+func Language(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		preference := r.URL.Query().Get(c.QueryParamLanguage)
+		if preference == "" {
+			if cookie, err := r.Cookie(c.CookieLanguageName); err == nil {
+				preference = cookie.Value
+			}
+		}
+
+		lang := i18n.Negotiate(r.Header.Get(c.HeaderAcceptLanguage), preference)
+		w.Header().Set(c.HeaderContentLanguage, string(lang))
+
+		next.ServeHTTP(w, r.WithContext(i18n.WithLang(r.Context(), lang)))
+	})
+}
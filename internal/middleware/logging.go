@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+	"github.com/sirupsen/logrus"
+)
+
+func RequestLogger(logger *logrus.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			entry := logger.WithFields(logrus.Fields{
+				logging.FieldRequestID: chimw.GetReqID(r.Context()),
+			})
+			ctx := logging.WithEntry(r.Context(), entry)
+			r = r.WithContext(ctx)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			log := logging.FromContext(r.Context()).WithFields(logrus.Fields{
+				"method":      r.Method,
+				"path":        r.URL.Path,
+				"status":      status,
+				"bytes":       ww.BytesWritten(),
+				"duration_ms": time.Since(start).Milliseconds(),
+				"remote_addr": r.RemoteAddr,
+				"user_agent":  r.UserAgent(),
+			})
+
+			switch {
+			case status >= http.StatusInternalServerError:
+				log.Error("Request completed")
+			case status >= http.StatusBadRequest:
+				log.Warn("Request completed")
+			default:
+				log.Info("Request completed")
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/metrics"
+)
+
+func Metrics(m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				route = rctx.RoutePattern()
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			m.HTTPRequestDuration.
+				WithLabelValues(r.Method, route, strconv.Itoa(status)).
+				Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+func BearerToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received := strings.TrimPrefix(r.Header.Get(c.HeaderAuthorization), "Bearer ")
+			if token == "" || subtle.ConstantTimeCompare([]byte(received), []byte(token)) != 1 {
+				apperror.Write(w, r, apperror.New(apperror.CodeUnauthorized, "Invalid or missing bearer token"))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
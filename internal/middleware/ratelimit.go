@@ -2,11 +2,15 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/goIdioms/conspect-generator/internal/apperror"
 	c "github.com/goIdioms/conspect-generator/internal/constants"
-	"github.com/sirupsen/logrus"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+	"github.com/goIdioms/conspect-generator/internal/metrics"
 )
 
 type RateLimiter struct {
@@ -14,28 +18,52 @@ type RateLimiter struct {
 	mu       sync.RWMutex
 	limit    int
 	window   time.Duration
-	logger   *logrus.Logger
+	metrics  *metrics.Metrics
+	exempt   map[string][]string
 }
 
-func NewRateLimiter(limit int, window time.Duration, logger *logrus.Logger) *RateLimiter {
+func NewRateLimiter(limit int, window time.Duration, m *metrics.Metrics) *RateLimiter {
 	rl := &RateLimiter{
 		requests: make(map[string][]time.Time),
 		limit:    limit,
 		window:   window,
-		logger:   logger,
+		metrics:  m,
+		exempt:   make(map[string][]string),
 	}
 
 	go rl.cleanup()
 	return rl
 }
 
+func (rl *RateLimiter) Exempt(pathPrefix string, methods ...string) {
+	for _, method := range methods {
+		rl.exempt[method] = append(rl.exempt[method], pathPrefix)
+	}
+}
+
+func (rl *RateLimiter) isExempt(r *http.Request) bool {
+	for _, prefix := range rl.exempt[r.Method] {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rl.isExempt(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		ip := getClientIP(r)
 
 		if !rl.allow(ip) {
-			rl.logger.Warnf("Rate limit exceeded for IP: %s", ip)
-			http.Error(w, "Слишком много запросов. Попробуйте позже.", http.StatusTooManyRequests)
+			logging.FromContext(r.Context()).Warnf("Rate limit exceeded for IP: %s", ip)
+			rl.metrics.RateLimitRejections.Inc()
+			w.Header().Set(c.HeaderRetryAfter, strconv.Itoa(int(rl.window.Seconds())))
+			apperror.Write(w, r, apperror.New(apperror.CodeQuotaExceeded, "rate limit exceeded"))
 			return
 		}
 
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/metrics"
+)
+
+func TestRateLimiterExemptsOnlyScopedRoutes(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Minute, metrics.New())
+	limiter.Exempt("/uploads/", http.MethodPatch, http.MethodHead)
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	send := func(method, path string) int {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if got := send(http.MethodPost, "/audio"); got != http.StatusNoContent {
+		t.Fatalf("first request = %d, want %d", got, http.StatusNoContent)
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodPatch, "/uploads/0123456789abcdef0123456789abcdef", http.StatusNoContent},
+		{http.MethodHead, "/uploads/0123456789abcdef0123456789abcdef", http.StatusNoContent},
+		{http.MethodPatch, "/styles/1", http.StatusTooManyRequests},
+		{http.MethodHead, "/healthz", http.StatusTooManyRequests},
+		{http.MethodPost, "/uploads/", http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		if got := send(tt.method, tt.path); got != tt.want {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
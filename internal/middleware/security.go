@@ -36,10 +36,11 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 				w.Header().Set(c.HeaderAccessControlAllowOrigin, origin)
 				w.Header().Set(c.HeaderAccessControlAllowMethods, c.CORSAllowMethods)
 				w.Header().Set(c.HeaderAccessControlAllowHeaders, c.CORSAllowHeaders)
+				w.Header().Set(c.HeaderAccessControlExposeHeaders, c.CORSExposeHeaders)
 				w.Header().Set(c.HeaderAccessControlMaxAge, c.CORSMaxAge)
 			}
 
-			if r.Method == c.MethodOPTIONS {
+			if r.Method == c.MethodOPTIONS && r.Header.Get(c.HeaderAccessControlRequestMethod) != "" {
 				w.WriteHeader(http.StatusOK)
 				return
 			}
@@ -49,10 +50,14 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 	}
 }
 
-func MaxBodySize(maxSize int64) func(http.Handler) http.Handler {
+func MaxBodySize(maxSize int64, overrides map[string]int64) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+			limit := maxSize
+			if override, ok := overrides[r.URL.Path]; ok {
+				limit = override
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	sessionApp "github.com/goIdioms/conspect-generator/internal/application/session"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+)
+
+func OptionalSession(sessionService *sessionApp.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(c.CookieSessionName)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			session, err := sessionService.ValidateSession(r.Context(), cookie.Value)
+			if err != nil {
+				logging.FromContext(r.Context()).Debugf("Ignoring invalid session cookie: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logging.AddField(r.Context(), logging.FieldUserID, session.UserID)
+			next.ServeHTTP(w, r.WithContext(sessionApp.WithUserID(r.Context(), session.UserID)))
+		})
+	}
+}
+
+func RequireSession(sessionService *sessionApp.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(c.CookieSessionName)
+			if err != nil {
+				apperror.Write(w, r, apperror.New(apperror.CodeUnauthorized, "Session cookie not found"))
+				return
+			}
+
+			session, err := sessionService.ValidateSession(r.Context(), cookie.Value)
+			if err != nil {
+				apperror.Write(w, r, apperror.Wrap(apperror.CodeSessionInvalid, "Invalid session", err))
+				return
+			}
+
+			logging.AddField(r.Context(), logging.FieldUserID, session.UserID)
+			next.ServeHTTP(w, r.WithContext(sessionApp.WithUserID(r.Context(), session.UserID)))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	c "github.com/goIdioms/conspect-generator/internal/constants"
+)
+
+func TusResumable(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == c.MethodOPTIONS {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set(c.HeaderTusResumable, c.TusVersion)
+		if r.Header.Get(c.HeaderTusResumable) != c.TusVersion {
+			w.Header().Set(c.HeaderTusVersion, c.TusVersion)
+			apperror.Write(w, r, apperror.New(apperror.CodeUnsupportedVersion, "Unsupported Tus-Resumable version").
+				WithField(c.HeaderTusResumable).
+				WithDetail("version", c.TusVersion))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
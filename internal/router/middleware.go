@@ -12,9 +12,13 @@ import (
 )
 
 func (r *Router) SetupMiddlewares() {
+	r.Router.Use(custommw.Deadlines(r.Server.ReadTimeout, r.Server.WriteTimeout))
 	r.Router.Use(middleware.RequestID)
 	r.Router.Use(middleware.RealIP)
-	r.Router.Use(middleware.Logger)
+	r.Router.Use(custommw.RequestLogger(r.Logger))
+	r.Router.Use(custommw.Tracing)
+	r.Router.Use(custommw.Metrics(r.Metrics))
+	r.Router.Use(custommw.Language)
 	r.Router.Use(middleware.Recoverer)
 
 	r.Router.Use(middleware.Timeout(10 * time.Minute))
@@ -33,7 +37,8 @@ func (r *Router) SetupMiddlewares() {
 		rateLimitWindow = constants.RateLimitWindow
 	}
 
-	rateLimiter := custommw.NewRateLimiter(rateLimit, rateLimitWindow, r.Logger)
+	rateLimiter := custommw.NewRateLimiter(rateLimit, rateLimitWindow, r.Metrics)
+	rateLimiter.Exempt(constants.UploadsPath+"/", constants.MethodPATCH, constants.MethodHEAD)
 	r.Router.Use(rateLimiter.Middleware)
 
 	maxBodySize, err := strconv.ParseInt(r.MaxBodySize, 10, 64)
@@ -42,7 +47,13 @@ func (r *Router) SetupMiddlewares() {
 		maxBodySize = constants.MaxBodySize
 	}
 
-	r.Router.Use(custommw.MaxBodySize(maxBodySize))
+	maxUploadSize, err := strconv.ParseInt(r.MaxUploadSize, 10, 64)
+	if err != nil {
+		r.Logger.Warnf("Invalid MAX_UPLOAD_BODY_SIZE value: %v, using default 1034MB", err)
+		maxUploadSize = constants.MaxUploadBodySize
+	}
+
+	r.Router.Use(custommw.MaxBodySize(maxBodySize, map[string]int64{"/audio": maxUploadSize}))
 }
 
 func getAllowedOrigins() []string {
@@ -1,17 +1,48 @@
 package router
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
 	"net/http"
 	"os"
+	"os/exec"
+	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	artifactApp "github.com/goIdioms/conspect-generator/internal/application/artifact"
+	cacheApp "github.com/goIdioms/conspect-generator/internal/application/cache"
+	conspectApp "github.com/goIdioms/conspect-generator/internal/application/conspect"
+	glossaryApp "github.com/goIdioms/conspect-generator/internal/application/glossary"
 	sessionApp "github.com/goIdioms/conspect-generator/internal/application/session"
+	styleApp "github.com/goIdioms/conspect-generator/internal/application/style"
+	uploadApp "github.com/goIdioms/conspect-generator/internal/application/upload"
 	userApp "github.com/goIdioms/conspect-generator/internal/application/user"
 	"github.com/goIdioms/conspect-generator/internal/config"
 	"github.com/goIdioms/conspect-generator/internal/constants"
+	domainArtifact "github.com/goIdioms/conspect-generator/internal/domain/artifact"
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
 	"github.com/goIdioms/conspect-generator/internal/handlers"
+	"github.com/goIdioms/conspect-generator/internal/health"
+	"github.com/goIdioms/conspect-generator/internal/i18n"
+	"github.com/goIdioms/conspect-generator/internal/infra/audio"
 	"github.com/goIdioms/conspect-generator/internal/infra/database"
+	"github.com/goIdioms/conspect-generator/internal/infra/diarization"
+	"github.com/goIdioms/conspect-generator/internal/infra/document"
+	"github.com/goIdioms/conspect-generator/internal/infra/prompts"
+	"github.com/goIdioms/conspect-generator/internal/infra/storage"
+	"github.com/goIdioms/conspect-generator/internal/infra/uploads"
+	"github.com/goIdioms/conspect-generator/internal/infra/video"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+	"github.com/goIdioms/conspect-generator/internal/metrics"
+	custommw "github.com/goIdioms/conspect-generator/internal/middleware"
 	"github.com/goIdioms/conspect-generator/internal/services"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+	"github.com/goIdioms/conspect-generator/internal/validators"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,14 +52,30 @@ type Router struct {
 	RateLimit       string
 	RateLimitWindow string
 	MaxBodySize     string
+	MaxUploadSize   string
+	MetricsAddr     string
+	MetricsToken    string
+	AdminToken      string
 	AudioHandler    *handlers.AudioHandler
 	AuthHandler     *handlers.AuthHandler
+	SessionService  *sessionApp.Service
+	HealthHandler   *handlers.HealthHandler
+	StyleHandler    *handlers.StyleHandler
+	ConspectHandler *handlers.ConspectHandler
+	GlossaryHandler *handlers.GlossaryHandler
+	UploadHandler   *handlers.UploadHandler
+	FileServer      http.Handler
+	Server          *config.ServerConfig
+	Health          *health.Service
+	Metrics         *metrics.Metrics
 	Database        *database.Database
 }
 
 func NewRouter() *Router {
-	logger := logrus.New()
-	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger := logging.New()
+	logger.AddHook(tracing.LogHook{})
+
+	warnMissingTranslations(logger)
 
 	dbCfg := config.NewDBConfig()
 	db, err := database.New(dbCfg, logger)
@@ -47,30 +94,162 @@ func NewRouter() *Router {
 
 	userRepo := database.NewUserRepository(db.GetDB())
 	sessionRepo := database.NewSessionRepository(db.GetDB())
+	conspectRepo := database.NewConspectRepository(db.GetDB())
+	revisionRepo := database.NewRevisionRepository(db.GetDB())
+	styleRepo := database.NewStyleRepository(db.GetDB())
+	glossaryRepo := database.NewGlossaryRepository(db.GetDB())
+	uploadRepo := database.NewUploadRepository(db.GetDB())
+	artifactRepo := database.NewArtifactRepository(db.GetDB())
+
+	m := metrics.New()
+	m.RegisterDB(db.GetDB(), sessionRepo.CountActive)
+
+	userService := userApp.NewService(userRepo)
+	sessionService := sessionApp.NewService(sessionRepo)
+	conspectService := conspectApp.NewService(conspectRepo, revisionRepo)
+	styleService := styleApp.NewService(styleRepo, loadStyles(logger))
+	glossaryService := glossaryApp.NewService(glossaryRepo)
+
+	uploadCfg := config.NewUploadConfig()
+	uploadStore, err := uploads.NewDiskStore(uploadCfg.Dir)
+	if err != nil {
+		logger.Fatalf("Failed to initialize upload storage: %v", err)
+	}
+	uploadService := uploadApp.NewService(uploadRepo, uploadStore, validators.MaxVideoFileSize, uploadCfg.TTL)
+	go uploadService.RunCleanup(context.Background(), constants.UploadCleanupInterval)
 
-	userService := userApp.NewService(userRepo, logger)
-	sessionService := sessionApp.NewService(sessionRepo, logger)
+	storageCfg := config.NewStorageConfig()
+	artifactStorage, fileServer := newArtifactStorage(logger, storageCfg)
+	artifactService := artifactApp.NewService(artifactRepo, artifactStorage, map[domainArtifact.Kind]time.Duration{
+		domainArtifact.KindSource:     storageCfg.SourceRetention,
+		domainArtifact.KindTranscript: storageCfg.Retention,
+		domainArtifact.KindDocument:   storageCfg.Retention,
+	}, storageCfg.URLTTL)
+	go artifactService.RunCleanup(context.Background(), constants.ArtifactCleanupInterval)
+
+	cacheRepo := database.NewCacheRepository(db.GetDB())
+	cacheService := cacheApp.NewService(cacheRepo, storageCfg.Retention)
+	go cacheService.RunCleanup(context.Background(), constants.CacheCleanupInterval)
 
 	authService := services.NewAuthService(oauthCfg, logger)
+	pdfService := services.NewPDFService(m)
+	preprocessor := newPreprocessor(logger)
+	transcriptionService := services.NewTranscriptionService(pdfService, preprocessor, newDiarizer(logger), newSlideExtractor(logger, preprocessor), newDocumentExtractor(logger), cacheRepo, m)
 	frontendURL := os.Getenv("FRONTEND_URL")
 
+	healthService := health.NewService(constants.HealthCheckTimeout, logger)
+	healthService.Register("postgres", db.Ping, constants.HealthCacheTTL)
+	healthService.Register("migrations", db.CheckMigrations, constants.HealthCacheTTL)
+	healthService.Register("font", func(context.Context) error { return pdfService.CheckFont() }, constants.HealthCacheTTL)
+	healthService.Register("temp_dir", health.TempDirWritable(os.TempDir()), constants.HealthCacheTTL)
+	healthService.Register("upload_dir", health.TempDirWritable(uploadCfg.Dir), constants.HealthCacheTTL)
+	if storageCfg.Provider == config.StorageProviderLocal {
+		healthService.Register("storage_dir", health.TempDirWritable(storageCfg.Dir), constants.HealthCacheTTL)
+	}
+	healthService.Register("openai", transcriptionService.Ping, constants.HealthAICacheTTL)
+
 	return &Router{
 		Router:          chi.NewRouter(),
 		Logger:          logger,
 		RateLimit:       os.Getenv("RATE_LIMIT_REQUESTS"),
 		RateLimitWindow: os.Getenv("RATE_LIMIT_WINDOW"),
 		MaxBodySize:     os.Getenv("MAX_BODY_SIZE"),
-		AudioHandler:    handlers.NewAudioHandler(logger),
-		AuthHandler:     handlers.NewAuthHandler(authService, userService, sessionService, logger, frontendURL),
+		MaxUploadSize:   os.Getenv("MAX_UPLOAD_BODY_SIZE"),
+		MetricsAddr:     os.Getenv("METRICS_ADDR"),
+		MetricsToken:    os.Getenv("METRICS_TOKEN"),
+		AdminToken:      os.Getenv("ADMIN_TOKEN"),
+		AudioHandler:    handlers.NewAudioHandler(pdfService, transcriptionService, conspectService, styleService, glossaryService, uploadService, artifactService, uploadCfg.MaxConcurrent),
+		AuthHandler:     handlers.NewAuthHandler(authService, userService, sessionService, frontendURL),
+		HealthHandler:   handlers.NewHealthHandler(healthService),
+		StyleHandler:    handlers.NewStyleHandler(styleService),
+		ConspectHandler: handlers.NewConspectHandler(conspectService, artifactService),
+		GlossaryHandler: handlers.NewGlossaryHandler(glossaryService),
+		UploadHandler:   handlers.NewUploadHandler(uploadService),
+		FileServer:      fileServer,
+		Server:          config.NewServerConfig(),
+		SessionService:  sessionService,
+		Health:          healthService,
+		Metrics:         m,
 		Database:        db,
 	}
 }
 
 func (r *Router) SetupRoutes() {
 	r.Router.Get("/", func(w http.ResponseWriter, req *http.Request) {
+		if !r.IsReady() {
+			apperror.Write(w, req, apperror.New(apperror.CodeServiceUnavailable, "Shutting down"))
+			return
+		}
 		w.Write([]byte("Healthy"))
 	})
-	r.Router.Post("/audio", r.AudioHandler.Handle)
+	r.Router.Get("/healthz", r.HealthHandler.Liveness)
+	r.Router.Get("/readyz", r.HealthHandler.Readiness)
+
+	switch {
+	case r.MetricsAddr != "":
+		r.Logger.Infof("Metrics are served on a separate listener %s", r.MetricsAddr)
+	case r.MetricsToken != "":
+		r.Router.With(custommw.BearerToken(r.MetricsToken)).Handle("/metrics", r.MetricsHandler())
+	default:
+		r.Logger.Warn("Neither METRICS_ADDR nor METRICS_TOKEN is set, /metrics is disabled")
+	}
+	conversion := custommw.Deadlines(r.Server.ConversionReadTimeout, r.Server.ConversionWriteTimeout)
+
+	r.Router.With(conversion, custommw.OptionalSession(r.SessionService)).Post("/audio", r.AudioHandler.Handle)
+	r.Router.With(conversion, custommw.OptionalSession(r.SessionService)).Post("/documents", r.AudioHandler.HandleDocument)
+	r.Router.Get("/styles", r.StyleHandler.List)
+
+	r.Router.Route("/conspects/{id}", func(conspects chi.Router) {
+		conspects.Use(custommw.OptionalSession(r.SessionService))
+		conspects.Get("/", r.ConspectHandler.Get)
+		conspects.Get("/html", r.ConspectHandler.HTML)
+		conspects.Get("/transcript", r.ConspectHandler.Transcript)
+		conspects.Put("/speakers", r.ConspectHandler.RenameSpeakers)
+		conspects.Get("/artifacts", r.ConspectHandler.Artifacts)
+		conspects.Get("/artifacts/{kind}", r.ConspectHandler.DownloadArtifact)
+		conspects.With(conversion).Post("/regenerate", r.AudioHandler.Regenerate)
+		conspects.With(conversion).Post("/render", r.AudioHandler.Render)
+		conspects.Put("/content", r.ConspectHandler.UpdateContent)
+		conspects.Get("/revisions", r.ConspectHandler.Revisions)
+		conspects.Get("/revisions/{number}", r.ConspectHandler.Revision)
+		conspects.Post("/revisions/{number}/rollback", r.ConspectHandler.Rollback)
+		conspects.Get("/diff", r.ConspectHandler.Diff)
+	})
+
+	if r.FileServer != nil {
+		r.Router.With(conversion).Handle(constants.FilesPath+"/*", r.FileServer)
+	}
+
+	r.Router.Route(constants.UploadsPath, func(uploads chi.Router) {
+		uploads.Use(custommw.OptionalSession(r.SessionService))
+		uploads.Use(custommw.TusResumable)
+		uploads.Options("/", r.UploadHandler.Options)
+		uploads.Post("/", r.UploadHandler.Create)
+		uploads.Head("/{id}", r.UploadHandler.Head)
+		uploads.With(conversion).Patch("/{id}", r.UploadHandler.Patch)
+		uploads.Delete("/{id}", r.UploadHandler.Delete)
+	})
+
+	r.Router.Route("/glossaries", func(glossaries chi.Router) {
+		glossaries.Use(custommw.RequireSession(r.SessionService))
+		glossaries.Get("/", r.GlossaryHandler.List)
+		glossaries.Post("/", r.GlossaryHandler.Create)
+		glossaries.Get("/{id}", r.GlossaryHandler.Get)
+		glossaries.Put("/{id}", r.GlossaryHandler.Update)
+		glossaries.Delete("/{id}", r.GlossaryHandler.Delete)
+	})
+
+	if r.AdminToken != "" {
+		r.Router.Route("/admin/styles", func(admin chi.Router) {
+			admin.Use(custommw.BearerToken(r.AdminToken))
+			admin.Post("/", r.StyleHandler.Create)
+			admin.Get("/{name}", r.StyleHandler.Get)
+			admin.Put("/{name}", r.StyleHandler.Update)
+			admin.Delete("/{name}", r.StyleHandler.Delete)
+		})
+	} else {
+		r.Logger.Warn("ADMIN_TOKEN is not set, /admin routes are disabled")
+	}
 
 	r.Router.Get("/auth/google/login", r.AuthHandler.GoogleLogin)
 	r.Router.Get("/auth/google/callback", r.AuthHandler.GoogleCallback)
@@ -78,9 +257,170 @@ func (r *Router) SetupRoutes() {
 	r.Router.Post("/auth/logout", r.AuthHandler.Logout)
 }
 
+func (r *Router) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(r.Metrics.Registry, promhttp.HandlerOpts{})
+}
+
+func (r *Router) SetReady(ready bool) {
+	r.Health.SetReady(ready)
+}
+
+func (r *Router) IsReady() bool {
+	return r.Health.IsReady()
+}
+
+func (r *Router) Drain(ctx context.Context) error {
+	return r.AudioHandler.Wait(ctx)
+}
+
 func (r *Router) Close() error {
 	if r.Database != nil {
 		return r.Database.Close()
 	}
 	return nil
 }
+
+func newPreprocessor(logger *logrus.Logger) domainConspect.Preprocessor {
+	cfg := config.NewAudioConfig()
+
+	switch cfg.Preprocessor {
+	case config.AudioPreprocessorPassthrough:
+		return audio.NewPassthroughPreprocessor()
+	case config.AudioPreprocessorFFmpeg, "":
+		_, ffmpegErr := exec.LookPath(cfg.FFmpegPath)
+		_, ffprobeErr := exec.LookPath(cfg.FFprobePath)
+		if ffmpegErr == nil && ffprobeErr == nil {
+			logger.Infof("Audio is normalized with ffmpeg: trim_silence=%t, speed=%.2f", cfg.TrimSilence, cfg.Speed)
+			return audio.NewFFmpegPreprocessor(cfg.FFmpegPath, cfg.FFprobePath, cfg.TrimSilence, cfg.Speed, cfg.Timeout)
+		}
+		if cfg.Preprocessor == config.AudioPreprocessorFFmpeg {
+			logger.Fatalf("ffmpeg and ffprobe are required for the ffmpeg audio preprocessor: %v", errors.Join(ffmpegErr, ffprobeErr))
+		}
+		logger.Warn("ffmpeg is not installed, audio is sent to Whisper without normalization")
+		return audio.NewPassthroughPreprocessor()
+	default:
+		logger.Fatalf("Unknown AUDIO_PREPROCESSOR %q", cfg.Preprocessor)
+		return nil
+	}
+}
+
+func newSlideExtractor(logger *logrus.Logger, preprocessor domainConspect.Preprocessor) domainConspect.SlideExtractor {
+	if _, ok := preprocessor.(*audio.FFmpegPreprocessor); !ok {
+		logger.Warn("Video uploads are disabled without the ffmpeg audio preprocessor")
+		return nil
+	}
+
+	audioCfg := config.NewAudioConfig()
+	cfg := config.NewVideoConfig()
+	logger.Infof("Video slides are sampled with ffmpeg: scene_threshold=%.2f, max_slides=%d", cfg.SceneThreshold, cfg.MaxSlides)
+	return video.NewFFmpegSlideExtractor(audioCfg.FFmpegPath, cfg.SceneThreshold, cfg.MaxSlides, cfg.Timeout)
+}
+
+func newDocumentExtractor(logger *logrus.Logger) domainConspect.DocumentExtractor {
+	cfg := config.NewDocumentConfig()
+
+	pdftotextPath := cfg.PDFToTextPath
+	if _, err := exec.LookPath(pdftotextPath); err != nil {
+		logger.Warn("pdftotext is not installed, PDF documents are rejected")
+		pdftotextPath = ""
+	}
+	return document.NewExtractor(pdftotextPath, cfg.Timeout, validators.MaxDocumentChars*utf8.UTFMax)
+}
+
+func newArtifactStorage(logger *logrus.Logger, cfg *config.StorageConfig) (domainArtifact.Storage, http.Handler) {
+	switch cfg.Provider {
+	case config.StorageProviderLocal:
+		signingKey := []byte(cfg.SigningKey)
+		if len(signingKey) == 0 {
+			signingKey = make([]byte, 32)
+			rand.Read(signingKey)
+			logger.Warn("STORAGE_SIGNING_KEY is not set, download links are invalidated on restart")
+		}
+		local, err := storage.NewLocalStorage(cfg.Dir, cfg.PublicURL, signingKey)
+		if err != nil {
+			logger.Fatalf("Failed to initialize local storage: %v", err)
+		}
+		logger.Infof("Artifacts are stored in %s", cfg.Dir)
+		return local, local
+	case config.StorageProviderS3:
+		s3, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PathStyle:       cfg.S3PathStyle,
+		}, cfg.Timeout)
+		if err != nil {
+			logger.Fatalf("Failed to initialize S3 storage: %v", err)
+		}
+		logger.Infof("Artifacts are stored in bucket %s at %s", cfg.S3Bucket, cfg.S3Endpoint)
+		return s3, nil
+	default:
+		logger.Fatalf("Unknown STORAGE_PROVIDER %q", cfg.Provider)
+		return nil, nil
+	}
+}
+
+func newDiarizer(logger *logrus.Logger) domainConspect.Diarizer {
+	cfg := config.NewDiarizationConfig()
+
+	switch cfg.Provider {
+	case config.DiarizationProviderHTTP:
+		if cfg.URL == "" {
+			logger.Fatal("DIARIZATION_URL is required for the http diarization provider")
+		}
+		logger.Infof("Speaker diarization uses %s", cfg.URL)
+		return diarization.NewHTTPDiarizer(cfg.URL, cfg.Timeout)
+	case config.DiarizationProviderFake:
+		logger.Warn("Speaker diarization uses the fake provider")
+		return diarization.NewFakeDiarizer(constants.FakeDiarizationSpeakers, constants.FakeDiarizationTurnLength)
+	case "":
+		return nil
+	default:
+		logger.Fatalf("Unknown DIARIZATION_PROVIDER %q", cfg.Provider)
+		return nil
+	}
+}
+
+func loadStyles(logger *logrus.Logger) []*style.Style {
+	styles, err := prompts.Builtin()
+	if err != nil {
+		logger.Fatalf("Failed to load builtin styles: %v", err)
+	}
+
+	dir := os.Getenv("PROMPT_STYLES_DIR")
+	if dir == "" {
+		return styles
+	}
+
+	overrides, err := prompts.Load(os.DirFS(dir))
+	if err != nil {
+		logger.Fatalf("Failed to load styles from %s: %v", dir, err)
+	}
+
+	for _, override := range overrides {
+		replaced := false
+		for i, st := range styles {
+			if st.Name == override.Name {
+				styles[i], replaced = override, true
+			}
+		}
+		if !replaced {
+			styles = append(styles, override)
+		}
+	}
+	logger.Infof("Loaded %d styles from %s", len(overrides), dir)
+	return styles
+}
+
+func warnMissingTranslations(logger *logrus.Logger) {
+	for _, code := range apperror.Codes() {
+		if !i18n.Has(i18n.DefaultLang, string(code)) {
+			logger.Warnf("No message for error code %s in the %s catalog", code, i18n.DefaultLang)
+		}
+	}
+	for _, missing := range i18n.Missing() {
+		logger.Warnf("Missing translation %s, falling back", missing)
+	}
+}
@@ -4,24 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/goIdioms/conspect-generator/internal/config"
 	"github.com/goIdioms/conspect-generator/internal/constants"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
 type AuthService struct {
-	config *config.OAuthConfig
-	oauth  *oauth2.Config
-	logger *logrus.Logger
+	config     *config.OAuthConfig
+	oauth      *oauth2.Config
+	httpClient *http.Client
+	logger     *logrus.Logger
 }
 
 func NewAuthService(cfg *config.OAuthConfig, logger *logrus.Logger) *AuthService {
 	return &AuthService{
-		config: cfg,
-		logger: logger,
+		config:     cfg,
+		httpClient: tracing.HTTPClient(),
+		logger:     logger,
 		oauth: &oauth2.Config{
 			ClientID:     cfg.Google.ClientID,
 			ClientSecret: cfg.Google.ClientSecret,
@@ -37,12 +41,16 @@ func (s *AuthService) GetAuthURL(state string) string {
 }
 
 func (s *AuthService) ExchangeCode(ctx context.Context, code string) (*oauth2.Token, error) {
-	return s.oauth.Exchange(ctx, code)
+	return s.oauth.Exchange(s.withHTTPClient(ctx), code)
 }
 
 func (s *AuthService) GetUserInfo(ctx context.Context, token *oauth2.Token) (*GoogleUser, error) {
-	client := s.oauth.Client(ctx, token)
-	resp, err := client.Get(constants.GoogleUserInfoURL)
+	client := s.oauth.Client(s.withHTTPClient(ctx), token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, constants.GoogleUserInfoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user info request: %w", err)
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user info: %w", err)
 	}
@@ -55,6 +63,10 @@ func (s *AuthService) GetUserInfo(ctx context.Context, token *oauth2.Token) (*Go
 	return &user, nil
 }
 
+func (s *AuthService) withHTTPClient(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, s.httpClient)
+}
+
 type GoogleUser struct {
 	ID            string `json:"id"`
 	Email         string `json:"email"`
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+	"github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type pageBudget struct {
+	pages     int
+	chars     int
+	maxTokens int
+	layout    style.Layout
+	bilingual bool
+}
+
+func (s *TranscriptionService) newPageBudget(pages int, layout style.Layout, bilingual bool) pageBudget {
+	budget := pageBudget{pages: pages, layout: layout, bilingual: bilingual}
+	if pages == 0 {
+		return budget
+	}
+
+	charsPerPage := s.pdfService.CharsPerPage(layout, bilingual)
+	budget.chars = pages*charsPerPage - charsPerPage/4
+	budget.maxTokens = 2*budget.chars/charsPerToken + maxTokensReserve
+	return budget
+}
+
+func (b pageBudget) fits(actual int) bool {
+	tolerance := int(float64(b.pages) * pageTolerance)
+	return actual >= b.pages-tolerance && actual <= b.pages+tolerance
+}
+
+func (s *TranscriptionService) fitPages(ctx context.Context, messages []openai.ChatCompletionMessage, summary string, st *style.Style, budget pageBudget) (string, int) {
+	ctx, span := tracer.Start(ctx, "pipeline.fit_pages")
+	defer span.End()
+
+	logger := logging.FromContext(ctx)
+	var actual int
+	for attempt := 0; ; attempt++ {
+		pages, err := s.pdfService.CountPages(ctx, domainConspect.StripCitations(summary), budget.layout, budget.bilingual)
+		if err != nil {
+			logger.Warnf("Failed to count pages, skipping page budget control: %v", err)
+			return summary, 0
+		}
+		actual = pages
+
+		if budget.fits(actual) || attempt == maxPageFitAttempts {
+			break
+		}
+
+		messages = append(messages,
+			openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: summary},
+			pageFitMessage(actual, budget),
+		)
+
+		revised, err := s.complete(ctx, "pipeline.fit_pages.revise", messages, budget.maxTokens)
+		if err != nil {
+			logger.Warnf("Failed to revise conspect length: %v", err)
+			break
+		}
+		if violations := st.Check(revised, 0); len(violations) > 0 {
+			logger.Warnf("Revised conspect violates the style contract, keeping the previous version: %v", violations)
+			break
+		}
+		summary = revised
+	}
+
+	span.SetAttributes(
+		attribute.Int("pdf.pages.target", budget.pages),
+		attribute.Int("pdf.pages.actual", actual),
+	)
+	logger.WithField("target_pages", budget.pages).WithField("actual_pages", actual).Info("Fitted conspect to page budget")
+	return summary, actual
+}
+
+func pageFitMessage(actual int, budget pageBudget) openai.ChatCompletionMessage {
+	action := "Expand"
+	if actual > budget.pages {
+		action = "Condense"
+	}
+
+	return openai.ChatCompletionMessage{
+		Role: openai.ChatMessageRoleUser,
+		Content: fmt.Sprintf(
+			"The previous answer fills %d pages, but %d are required. %s the whole conspect to about %d characters, keeping the same style and requirements.",
+			actual, budget.pages, action, budget.chars,
+		),
+	}
+}
@@ -1,6 +1,10 @@
 package services
 
 const (
+	defaultConspectLanguage = "ru"
+	maxSummaryAttempts      = 3
+	promptVersion           = "1"
+
 	handwrittenFont     = "handwritten"
 	handwrittenFontPath = "./fonts/MarckScript.ttf"
 
@@ -10,4 +14,33 @@ const (
 	defaultPageWidth   = 595.0
 	defaultMaxWidth    = 595.0 - 40.0*2
 	defaultFontSize    = 14.0
+
+	titleFontSize  = 20.0
+	titleSpacing   = 30.0
+	footerFontSize = 10.0
+	footerY        = 822.0
+	columnGap      = 20.0
+
+	headingFontSize  = 17.0
+	bulletIndent     = 15.0
+	cornellCueRatio  = 0.3
+	cornellSeparator = "::"
+	glossarySpacing  = 30.0
+	slideMaxHeight   = 300.0
+	slideSpacing     = 10.0
+
+	annotationFontSize = 8.0
+	annotationX        = 6.0
+	annotationOffsetY  = 3.0
+
+	lineHeight           = 20.0
+	pageBottom           = 800.0
+	pageFillRatio        = 0.85
+	fallbackCharsPerPage = 1800
+	charWidthSample      = "Съешь же ещё этих мягких французских булок, да выпей чаю. The quick brown fox jumps over the lazy dog."
+
+	charsPerToken      = 3
+	pageTolerance      = 0.1
+	maxPageFitAttempts = 3
+	maxTokensReserve   = 256
 )
@@ -1,16 +1,29 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"image/jpeg"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
+	"github.com/goIdioms/conspect-generator/internal/i18n"
+	"github.com/goIdioms/conspect-generator/internal/metrics"
 	"github.com/signintech/gopdf"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type PDFService struct {
-	pdf    *gopdf.GoPdf
-	params PDFParams
+	mu        sync.Mutex
+	pdf       *gopdf.GoPdf
+	params    PDFParams
+	metrics   *metrics.Metrics
+	charWidth float64
 }
 
 type PDFParams struct {
@@ -24,7 +37,7 @@ type PDFParams struct {
 	fontName string
 }
 
-func NewPDFService() *PDFService {
+func NewPDFService(m *metrics.Metrics) *PDFService {
 	return &PDFService{
 		pdf: &gopdf.GoPdf{},
 		params: PDFParams{
@@ -36,36 +49,191 @@ func NewPDFService() *PDFService {
 			fontSize:    defaultFontSize,
 			fontName:    handwrittenFont,
 		},
+		metrics: m,
 	}
 }
 
-func (s *PDFService) CreatePDF(textContent string) ([]byte, error) {
-	textContent = s.CleanTextForPDF(textContent)
+func (s *PDFService) CheckFont() error {
+	if _, err := os.Stat(handwrittenFontPath); err != nil {
+		return fmt.Errorf("font %s is not available: %w", handwrittenFontPath, err)
+	}
+	return nil
+}
+
+func (s *PDFService) CreatePDF(
+	ctx context.Context,
+	textContent string,
+	layout style.Layout,
+	annotations map[int]string,
+	slides map[int][]domainConspect.Slide,
+	glossary []domainConspect.GlossaryEntry,
+) ([]byte, int, error) {
+	return s.render(ctx, func() {
+		s.writeBody(textContent, layout, annotations, slides)
+		s.writeGlossary(i18n.T(i18n.FromContext(ctx), i18n.KeyPDFGlossary, nil), glossary)
+	})
+}
+
+func (s *PDFService) CreateBilingualPDF(ctx context.Context, left, right string, slides map[int][]domainConspect.Slide, glossary []domainConspect.GlossaryEntry) ([]byte, int, error) {
+	return s.render(ctx, func() {
+		s.FormatColumnsForPDF(s.CleanTextForPDF(left), s.CleanTextForPDF(right), slides)
+		s.writeGlossary(i18n.T(i18n.FromContext(ctx), i18n.KeyPDFGlossary, nil), glossary)
+	})
+}
+
+func (s *PDFService) CountPages(ctx context.Context, textContent string, layout style.Layout, bilingual bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.compose(ctx, func() {
+		if bilingual {
+			cleaned := s.CleanTextForPDF(textContent)
+			s.FormatColumnsForPDF(cleaned, cleaned, nil)
+			return
+		}
+		s.writeBody(textContent, layout, nil, nil)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return s.pdf.GetNumberOfPages(), nil
+}
+
+func (s *PDFService) CharsPerPage(layout style.Layout, bilingual bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.charWidth == 0 {
+		if err := s.startDocument(); err != nil {
+			return fallbackCharsPerPage
+		}
+		width, err := s.pdf.MeasureTextWidth(charWidthSample)
+		if err != nil || width == 0 {
+			return fallbackCharsPerPage
+		}
+		s.charWidth = width / float64(len([]rune(charWidthSample)))
+	}
+
+	lineWidth := s.params.maxWidth
+	switch {
+	case bilingual:
+		lineWidth = (s.params.maxWidth - columnGap) / 2
+	case layout == style.LayoutCornell:
+		lineWidth = s.params.maxWidth*(1-cornellCueRatio) - columnGap/2
+	case layout == style.LayoutOutline:
+		lineWidth -= bulletIndent
+	}
+
+	linesPerPage := (pageBottom - s.params.marginTop) / lineHeight
+	return int(lineWidth / s.charWidth * linesPerPage * pageFillRatio)
+}
+
+func (s *PDFService) writeBody(textContent string, layout style.Layout, annotations map[int]string, slides map[int][]domainConspect.Slide) {
+	switch layout {
+	case style.LayoutOutline:
+		s.FormatOutlineForPDF(textContent, annotations, slides)
+	case style.LayoutCornell:
+		s.FormatCornellForPDF(textContent, annotations, slides)
+	default:
+		s.FormatTextForPDF(s.CleanTextForPDF(textContent), annotations, slides)
+	}
+}
+
+func (s *PDFService) render(ctx context.Context, writeBody func()) ([]byte, int, error) {
+	_, span := tracer.Start(ctx, "pipeline.render")
+	defer span.End()
+	defer s.metrics.ObserveStage(metrics.StageRender, time.Now())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.compose(ctx, writeBody); err != nil {
+		return nil, 0, err
+	}
+
+	pages := s.pdf.GetNumberOfPages()
+	s.metrics.PDFPages.Observe(float64(pages))
+	span.SetAttributes(attribute.Int("pdf.pages", pages))
+
+	if pdfBytes, err := s.SavePDF(); err != nil {
+		return nil, 0, fmt.Errorf("failed to save PDF: %w", err)
+	} else {
+		return pdfBytes, pages, nil
+	}
+}
+
+func (s *PDFService) compose(ctx context.Context, writeBody func()) error {
+	if err := s.startDocument(); err != nil {
+		return err
+	}
 
+	lang := i18n.FromContext(ctx)
+	if err := s.writeTitle(i18n.T(lang, i18n.KeyPDFTitle, nil)); err != nil {
+		return err
+	}
+
+	writeBody()
+
+	return s.writeFooters(lang)
+}
+
+func (s *PDFService) startDocument() error {
+	s.pdf = &gopdf.GoPdf{}
 	s.pdf.Start(gopdf.Config{PageSize: *gopdf.PageSizeA4})
 	s.pdf.AddPage()
 
 	if _, err := os.Stat(handwrittenFontPath); err == nil {
 		s.params.fontName = handwrittenFont
 		if err := s.pdf.AddTTFFont(s.params.fontName, handwrittenFontPath); err != nil {
-			return nil, fmt.Errorf("failed to add font: %w", err)
+			return fmt.Errorf("failed to add font: %w", err)
 		}
 	}
 
 	if err := s.pdf.SetFont(s.params.fontName, "", s.params.fontSize); err != nil {
-		return nil, fmt.Errorf("failed to set font: %w", err)
+		return fmt.Errorf("failed to set font: %w", err)
 	}
 
 	s.pdf.SetX(s.params.marginLeft)
 	s.pdf.SetY(s.params.marginTop)
+	return nil
+}
 
-	s.FormatTextForPDF(textContent)
+func (s *PDFService) writeTitle(title string) error {
+	if err := s.pdf.SetFontSize(titleFontSize); err != nil {
+		return fmt.Errorf("failed to set title font size: %w", err)
+	}
 
-	if pdfBytes, err := s.SavePDF(); err != nil {
-		return nil, fmt.Errorf("failed to save PDF: %w", err)
-	} else {
-		return pdfBytes, nil
+	width, _ := s.pdf.MeasureTextWidth(title)
+	s.pdf.SetX(s.params.marginLeft + (s.params.maxWidth-width)/2)
+	s.pdf.Cell(nil, title)
+	s.pdf.Br(titleSpacing)
+	s.pdf.SetX(s.params.marginLeft)
+
+	if err := s.pdf.SetFontSize(s.params.fontSize); err != nil {
+		return fmt.Errorf("failed to restore font size: %w", err)
 	}
+	return nil
+}
+
+func (s *PDFService) writeFooters(lang i18n.Lang) error {
+	total := s.pdf.GetNumberOfPages()
+	if err := s.pdf.SetFontSize(footerFontSize); err != nil {
+		return fmt.Errorf("failed to set footer font size: %w", err)
+	}
+
+	for page := 1; page <= total; page++ {
+		if err := s.pdf.SetPage(page); err != nil {
+			return fmt.Errorf("failed to select page %d: %w", page, err)
+		}
+
+		footer := i18n.T(lang, i18n.KeyPDFFooter, map[string]any{"page": page, "total": total})
+		width, _ := s.pdf.MeasureTextWidth(footer)
+		s.pdf.SetX(s.params.marginLeft + (s.params.maxWidth-width)/2)
+		s.pdf.SetY(footerY)
+		s.pdf.Cell(nil, footer)
+	}
+
+	return s.pdf.SetFontSize(s.params.fontSize)
 }
 
 func (s *PDFService) CleanTextForPDF(text string) string {
@@ -112,14 +280,18 @@ func (s *PDFService) CleanTextForPDF(text string) string {
 	return result
 }
 
-func (s *PDFService) FormatTextForPDF(textContent string) {
+func (s *PDFService) FormatTextForPDF(textContent string, annotations map[int]string, slides map[int][]domainConspect.Slide) {
 	paragraphs := strings.Split(textContent, "\n")
 
+	index := 0
 	for _, paragraph := range paragraphs {
 		if paragraph == "" {
 			s.pdf.SetY(s.pdf.GetY() + 10)
 			continue
 		}
+		s.drawSlides(slides[index])
+		s.annotate(annotations[index])
+		index++
 
 		words := strings.Fields(paragraph)
 		currentLine := ""
@@ -161,6 +333,243 @@ func (s *PDFService) FormatTextForPDF(textContent string) {
 	}
 }
 
+func (s *PDFService) FormatColumnsForPDF(left, right string, slides map[int][]domainConspect.Slide) {
+	columnWidth := (s.params.maxWidth - columnGap) / 2
+	rightX := s.params.marginLeft + columnWidth + columnGap
+
+	leftParagraphs := splitParagraphs(left)
+	rightParagraphs := splitParagraphs(right)
+
+	for i := 0; i < max(len(leftParagraphs), len(rightParagraphs)); i++ {
+		var leftLines, rightLines []string
+		if i < len(leftParagraphs) {
+			leftLines = s.wrapLines(leftParagraphs[i], columnWidth)
+		}
+		if i < len(rightParagraphs) {
+			rightLines = s.wrapLines(rightParagraphs[i], columnWidth)
+		}
+
+		s.drawSlides(slides[i])
+		s.writeColumns(leftLines, rightLines, rightX)
+		s.pdf.SetY(s.pdf.GetY() + 10)
+	}
+}
+
+func (s *PDFService) FormatOutlineForPDF(textContent string, annotations map[int]string, slides map[int][]domainConspect.Slide) {
+	index := 0
+	for _, line := range strings.Split(textContent, "\n") {
+		trimmed := strings.TrimSpace(strings.ReplaceAll(line, "**", ""))
+		if trimmed != "" {
+			if strings.HasPrefix(trimmed, "#") {
+				s.pdf.SetY(s.pdf.GetY() + 10)
+			}
+			s.drawSlides(slides[index])
+			s.annotate(annotations[index])
+			index++
+		}
+
+		switch {
+		case trimmed == "":
+			s.pdf.SetY(s.pdf.GetY() + 10)
+		case strings.HasPrefix(trimmed, "#"):
+			s.pdf.SetFontSize(headingFontSize)
+			for _, wrapped := range s.wrapLines(strings.TrimLeft(trimmed, "# "), s.params.maxWidth) {
+				s.writeLine(s.params.marginLeft, wrapped)
+			}
+			s.pdf.SetFontSize(s.params.fontSize)
+		case isBullet(trimmed):
+			level := (len(line) - len(strings.TrimLeft(line, " \t"))) / 2
+			indent := bulletIndent * float64(level+1)
+			text := strings.TrimSpace(trimmed[strings.IndexAny(trimmed, " ")+1:])
+
+			for i, wrapped := range s.wrapLines(text, s.params.maxWidth-indent) {
+				if i == 0 {
+					s.writeLine(s.params.marginLeft+indent-bulletIndent, "• "+wrapped)
+				} else {
+					s.writeLine(s.params.marginLeft+indent, wrapped)
+				}
+			}
+		default:
+			for _, wrapped := range s.wrapLines(trimmed, s.params.maxWidth) {
+				s.writeLine(s.params.marginLeft, wrapped)
+			}
+		}
+	}
+}
+
+func (s *PDFService) FormatCornellForPDF(textContent string, annotations map[int]string, slides map[int][]domainConspect.Slide) {
+	cueWidth := s.params.maxWidth*cornellCueRatio - columnGap/2
+	noteWidth := s.params.maxWidth - cueWidth - columnGap
+	noteX := s.params.marginLeft + cueWidth + columnGap
+
+	for i, paragraph := range splitParagraphs(s.CleanTextForPDF(textContent)) {
+		s.drawSlides(slides[i])
+		cue, note, ok := strings.Cut(paragraph, cornellSeparator)
+		if !ok {
+			s.pdf.SetY(s.pdf.GetY() + 10)
+			s.annotate(annotations[i])
+			for _, wrapped := range s.wrapLines(paragraph, s.params.maxWidth) {
+				s.writeLine(s.params.marginLeft, wrapped)
+			}
+			continue
+		}
+
+		s.annotate(annotations[i])
+		s.writeColumns(
+			s.wrapLines(strings.TrimSpace(cue), cueWidth),
+			s.wrapLines(strings.TrimSpace(note), noteWidth),
+			noteX,
+		)
+		s.pdf.SetY(s.pdf.GetY() + 10)
+	}
+}
+
+func (s *PDFService) writeGlossary(title string, entries []domainConspect.GlossaryEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	s.pdf.SetY(s.pdf.GetY() + glossarySpacing)
+	s.pdf.SetFontSize(headingFontSize)
+	s.writeLine(s.params.marginLeft, title)
+	s.pdf.SetFontSize(s.params.fontSize)
+
+	for _, entry := range entries {
+		for i, wrapped := range s.wrapLines(entry.Term+" — "+entry.Definition, s.params.maxWidth-bulletIndent) {
+			if i == 0 {
+				s.writeLine(s.params.marginLeft, wrapped)
+			} else {
+				s.writeLine(s.params.marginLeft+bulletIndent, wrapped)
+			}
+		}
+	}
+}
+
+func (s *PDFService) writeColumns(leftLines, rightLines []string, rightX float64) {
+	for j := 0; j < max(len(leftLines), len(rightLines)); j++ {
+		y := s.pdf.GetY()
+		if j < len(leftLines) {
+			s.pdf.SetX(s.params.marginLeft)
+			s.pdf.Cell(nil, leftLines[j])
+		}
+		if j < len(rightLines) {
+			s.pdf.SetXY(rightX, y)
+			s.pdf.Cell(nil, rightLines[j])
+		}
+		s.pdf.SetX(s.params.marginLeft)
+		s.pdf.Br(20)
+
+		if s.pdf.GetY() > 800 {
+			s.pdf.AddPage()
+			s.pdf.SetY(s.params.marginTop)
+		}
+	}
+}
+
+func (s *PDFService) drawSlides(slides []domainConspect.Slide) {
+	for _, slide := range slides {
+		config, err := jpeg.DecodeConfig(bytes.NewReader(slide.Image))
+		if err != nil || config.Width == 0 || config.Height == 0 {
+			continue
+		}
+		holder, err := gopdf.ImageHolderByBytes(slide.Image)
+		if err != nil {
+			continue
+		}
+
+		width := s.params.maxWidth
+		height := width * float64(config.Height) / float64(config.Width)
+		if height > slideMaxHeight {
+			width, height = width*slideMaxHeight/height, slideMaxHeight
+		}
+
+		if s.pdf.GetY()+height > pageBottom {
+			s.pdf.AddPage()
+			s.pdf.SetY(s.params.marginTop)
+		}
+
+		y := s.pdf.GetY()
+		if err := s.pdf.ImageByHolder(holder, s.params.marginLeft+(s.params.maxWidth-width)/2, y, &gopdf.Rect{W: width, H: height}); err != nil {
+			continue
+		}
+		s.pdf.SetX(s.params.marginLeft)
+		s.annotate(slide.Label())
+		s.pdf.SetXY(s.params.marginLeft, y+height+slideSpacing)
+	}
+}
+
+func (s *PDFService) annotate(label string) {
+	if label == "" {
+		return
+	}
+	if s.pdf.GetY() > pageBottom {
+		s.pdf.AddPage()
+		s.pdf.SetY(s.params.marginTop)
+	}
+
+	x, y := s.pdf.GetX(), s.pdf.GetY()
+	s.pdf.SetFontSize(annotationFontSize)
+	s.pdf.SetXY(annotationX, y+annotationOffsetY)
+	s.pdf.Cell(nil, label)
+	s.pdf.SetFontSize(s.params.fontSize)
+	s.pdf.SetXY(x, y)
+}
+
+func (s *PDFService) writeLine(x float64, text string) {
+	s.pdf.SetX(x)
+	s.pdf.Cell(nil, text)
+	s.pdf.Br(20)
+
+	if s.pdf.GetY() > 800 {
+		s.pdf.AddPage()
+		s.pdf.SetY(s.params.marginTop)
+	}
+}
+
+func isBullet(line string) bool {
+	for _, marker := range []string{"- ", "* ", "• ", "+ "} {
+		if strings.HasPrefix(line, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PDFService) wrapLines(paragraph string, width float64) []string {
+	var lines []string
+	currentLine := ""
+
+	for _, word := range strings.Fields(paragraph) {
+		testLine := currentLine
+		if testLine != "" {
+			testLine += " "
+		}
+		testLine += word
+
+		if measured, _ := s.pdf.MeasureTextWidth(testLine); measured > width && currentLine != "" {
+			lines = append(lines, currentLine)
+			currentLine = word
+		} else {
+			currentLine = testLine
+		}
+	}
+
+	if currentLine != "" {
+		lines = append(lines, currentLine)
+	}
+	return lines
+}
+
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paragraphs = append(paragraphs, line)
+		}
+	}
+	return paragraphs
+}
+
 func (s *PDFService) SavePDF() ([]byte, error) {
 	tmpFile, err := os.CreateTemp("", "pdf-*.pdf")
 	if err != nil {
@@ -2,71 +2,625 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	domainGlossary "github.com/goIdioms/conspect-generator/internal/domain/glossary"
+	"github.com/goIdioms/conspect-generator/internal/domain/style"
+	"github.com/goIdioms/conspect-generator/internal/i18n"
+	"github.com/goIdioms/conspect-generator/internal/logging"
+	"github.com/goIdioms/conspect-generator/internal/metrics"
+	"github.com/goIdioms/conspect-generator/internal/tracing"
+	"github.com/goIdioms/conspect-generator/internal/validators"
 	"github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+var tracer = tracing.Tracer("github.com/goIdioms/conspect-generator/internal/services")
+
 type TranscriptionService struct {
-	apiKey string
-	client *openai.Client
+	apiKey       string
+	client       *openai.Client
+	pdfService   *PDFService
+	preprocessor domainConspect.Preprocessor
+	diarizer     domainConspect.Diarizer
+	slides       domainConspect.SlideExtractor
+	documents    domainConspect.DocumentExtractor
+	cache        domainConspect.Cache
+	metrics      *metrics.Metrics
 }
 
-func NewTranscriptionService() *TranscriptionService {
+func NewTranscriptionService(
+	pdfService *PDFService,
+	preprocessor domainConspect.Preprocessor,
+	diarizer domainConspect.Diarizer,
+	slides domainConspect.SlideExtractor,
+	documents domainConspect.DocumentExtractor,
+	cache domainConspect.Cache,
+	m *metrics.Metrics,
+) *TranscriptionService {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	cfg := openai.DefaultConfig(apiKey)
+	cfg.HTTPClient = tracing.HTTPClient()
+
 	return &TranscriptionService{
-		apiKey: os.Getenv("OPENAI_API_KEY"),
-		client: openai.NewClient(os.Getenv("OPENAI_API_KEY")),
+		apiKey:       apiKey,
+		client:       openai.NewClientWithConfig(cfg),
+		pdfService:   pdfService,
+		preprocessor: preprocessor,
+		diarizer:     diarizer,
+		slides:       slides,
+		documents:    documents,
+		cache:        cache,
+		metrics:      m,
+	}
+}
+
+func (s *TranscriptionService) Ping(ctx context.Context) error {
+	if s.apiKey == "" {
+		return fmt.Errorf("OPENAI_API_KEY is not configured")
+	}
+	if _, err := s.client.ListModels(ctx); err != nil {
+		return fmt.Errorf("openai is unreachable: %w", err)
+	}
+	return nil
+}
+
+func (s *TranscriptionService) SummarizeAudio(ctx context.Context, filePath, audioHash string, opts domainConspect.Options, st *style.Style, glossary *domainGlossary.Glossary) (*domainConspect.Result, error) {
+	var prompt string
+	if glossary != nil {
+		prompt = glossary.WhisperPrompt()
+	}
+
+	transcribed, err := s.transcribeAudio(ctx, filePath, audioHash, opts, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.SummarizeTranscript(ctx, transcribed.Transcript, transcribed.DetectedLanguage, transcribed.Speakers, opts, st, glossary)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Slides && transcribed.Video {
+		result.Slides = s.extractSlides(ctx, filePath)
+	}
+	return result, nil
+}
+
+func (s *TranscriptionService) SummarizeDocument(ctx context.Context, transcript domainConspect.Transcript, opts domainConspect.Options, st *style.Style, glossary *domainGlossary.Glossary) (*domainConspect.Result, error) {
+	return s.SummarizeTranscript(ctx, transcript, domainConspect.DetectLanguage(transcript.Text), nil, opts, st, glossary)
+}
+
+func (s *TranscriptionService) SummarizeTranscript(ctx context.Context, transcript domainConspect.Transcript, detected domainConspect.Language, speakers domainConspect.Speakers, opts domainConspect.Options, st *style.Style, glossary *domainGlossary.Glossary) (*domainConspect.Result, error) {
+	result := &domainConspect.Result{
+		Transcript:       transcript,
+		DetectedLanguage: detected,
+		Speakers:         speakers,
+	}
+	if glossary != nil {
+		result.Transcript = result.Transcript.Correct(glossary.Correct)
+	}
+
+	spokenLanguage := opts.SourceLanguage
+	if spokenLanguage.IsZero() {
+		spokenLanguage = result.DetectedLanguage
+	}
+
+	result.TargetLanguage = opts.TargetLanguage
+	if result.TargetLanguage.IsZero() {
+		result.TargetLanguage = spokenLanguage
+	}
+	if result.TargetLanguage.IsZero() {
+		result.TargetLanguage, _ = domainConspect.NewLanguage(defaultConspectLanguage)
+	}
+
+	translate := opts.Bilingual && !spokenLanguage.IsZero() && spokenLanguage != result.TargetLanguage
+	budget := s.newPageBudget(opts.Pages, st.Layout, translate)
+	result.TargetPages = opts.Pages
+
+	var summaryKey string
+	if s.cache != nil {
+		summaryKey = domainConspect.SummaryKey{
+			TranscriptHash: result.Transcript.Hash(),
+			PromptVersion:  promptVersion + ":" + st.Version(),
+			Model:          openai.GPT4oMini,
+			Params:         summaryParams(opts, result.TargetLanguage, spokenLanguage, translate, budget, glossary),
+		}.Hash()
+		if cached := s.cachedSummary(ctx, summaryKey); cached != nil {
+			result.Summary = cached.Summary
+			result.SourceSummary = cached.SourceSummary
+			result.ActualPages = cached.ActualPages
+			result.Citations = cached.Citations
+			result.Glossary = cached.Glossary
+			return result, nil
+		}
+	}
+
+	messages, err := s.BuildSummaryMessages(result.Transcript, opts, result.TargetLanguage, st, glossary, budget)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeSummarizationFailed, "Failed to build summary prompt", err)
+	}
+
+	result.Summary, err = s.summarize(ctx, messages, st, budget)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeSummarizationFailed, "Failed to summarize transcript", err)
+	}
+
+	if opts.Pages > 0 {
+		result.Summary, result.ActualPages = s.fitPages(ctx, messages, result.Summary, st, budget)
+	}
+	result.Summary, result.Citations = domainConspect.ExtractCitations(result.Summary, result.Transcript.Segments)
+
+	if translate {
+		messages := s.BuildTranslationMessages(result.Summary, result.TargetLanguage, spokenLanguage)
+
+		result.SourceSummary, err = s.complete(ctx, "pipeline.translate", messages, 0)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeSummarizationFailed, "Failed to translate conspect", err)
+		}
+	}
+
+	if glossary != nil {
+		result.Glossary = s.defineTerms(ctx, glossary, result.Transcript, result.TargetLanguage)
+	}
+
+	if summaryKey != "" {
+		err := s.cache.SaveSummary(ctx, summaryKey, &domainConspect.CachedSummary{
+			Summary:       result.Summary,
+			SourceSummary: result.SourceSummary,
+			ActualPages:   result.ActualPages,
+			Citations:     result.Citations,
+			Glossary:      result.Glossary,
+		})
+		if err != nil {
+			logging.FromContext(ctx).Warnf("Failed to cache summary: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *TranscriptionService) transcribeAudio(ctx context.Context, filePath, audioHash string, opts domainConspect.Options, prompt string) (*domainConspect.CachedTranscript, error) {
+	var key string
+	if s.cache != nil && audioHash != "" {
+		key = domainConspect.TranscriptKey{
+			AudioHash: audioHash,
+			Model:     openai.Whisper1,
+			Language:  opts.SourceLanguage,
+			Prompt:    prompt,
+			Diarize:   opts.Diarize && s.CanDiarize(),
+		}.Hash()
+		if cached := s.cachedTranscript(ctx, key); cached != nil {
+			return cached, nil
+		}
+	}
+
+	prepared, err := s.prepare(ctx, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if prepared.Path != filePath {
+		defer os.Remove(prepared.Path)
+	}
+
+	resp, err := s.transcribe(ctx, prepared.Path, opts.SourceLanguage, prompt)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeTranscriptionFailed, "Failed to transcribe audio", err)
+	}
+
+	transcribed := &domainConspect.CachedTranscript{
+		Transcript: newTranscript(resp),
+		Video:      prepared.Source.Video,
+	}
+	transcribed.DetectedLanguage, _ = domainConspect.LanguageFromName(resp.Language)
+
+	if opts.Diarize {
+		transcribed.Speakers = s.diarize(ctx, prepared.Path, &transcribed.Transcript)
+		if transcribed.Speakers == nil {
+			key = ""
+		}
+	}
+
+	transcribed.Transcript = transcribed.Transcript.Rebase(prepared.Speed, prepared.Offset)
+	if prepared.Source.Duration > 0 {
+		transcribed.Transcript.Duration = prepared.Source.Duration
+	}
+
+	if key != "" {
+		if err := s.cache.SaveTranscript(ctx, key, transcribed); err != nil {
+			logging.FromContext(ctx).Warnf("Failed to cache transcript: %v", err)
+		}
+	}
+	return transcribed, nil
+}
+
+func (s *TranscriptionService) cachedTranscript(ctx context.Context, key string) *domainConspect.CachedTranscript {
+	cached, err := s.cache.FindTranscript(ctx, key)
+	if err != nil {
+		if !errors.Is(err, domainConspect.ErrCacheMiss) {
+			logging.FromContext(ctx).Warnf("Failed to read transcript cache: %v", err)
+		}
+		s.metrics.CacheLookups.WithLabelValues(metrics.StageTranscribe, metrics.CacheMiss).Inc()
+		return nil
+	}
+
+	s.metrics.CacheLookups.WithLabelValues(metrics.StageTranscribe, metrics.CacheHit).Inc()
+	logging.FromContext(ctx).Info("Reusing cached transcript")
+	return cached
+}
+
+func (s *TranscriptionService) cachedSummary(ctx context.Context, key string) *domainConspect.CachedSummary {
+	cached, err := s.cache.FindSummary(ctx, key)
+	if err != nil {
+		if !errors.Is(err, domainConspect.ErrCacheMiss) {
+			logging.FromContext(ctx).Warnf("Failed to read summary cache: %v", err)
+		}
+		s.metrics.CacheLookups.WithLabelValues(metrics.StageSummarize, metrics.CacheMiss).Inc()
+		return nil
+	}
+
+	s.metrics.CacheLookups.WithLabelValues(metrics.StageSummarize, metrics.CacheHit).Inc()
+	logging.FromContext(ctx).Info("Reusing cached summary")
+	return cached
+}
+
+func summaryParams(opts domainConspect.Options, target, spoken domainConspect.Language, translate bool, budget pageBudget, glossary *domainGlossary.Glossary) string {
+	params := struct {
+		Pages       int                   `json:"pages"`
+		Notes       string                `json:"notes"`
+		StyleParams map[string]string     `json:"style_params"`
+		Target      string                `json:"target"`
+		Spoken      string                `json:"spoken"`
+		Translate   bool                  `json:"translate"`
+		Chars       int                   `json:"chars"`
+		MaxTokens   int                   `json:"max_tokens"`
+		Glossary    []domainGlossary.Term `json:"glossary"`
+	}{
+		Pages:       opts.Pages,
+		Notes:       opts.Notes,
+		StyleParams: opts.StyleParams,
+		Target:      target.Code(),
+		Spoken:      spoken.Code(),
+		Translate:   translate,
+		Chars:       budget.chars,
+		MaxTokens:   budget.maxTokens,
+	}
+	if glossary != nil {
+		params.Glossary = glossary.Terms
+	}
+
+	encoded, _ := json.Marshal(params)
+	return string(encoded)
+}
+
+func (s *TranscriptionService) prepare(ctx context.Context, filePath string) (*domainConspect.PreparedAudio, error) {
+	ctx, span := tracer.Start(ctx, "pipeline.preprocess")
+	defer span.End()
+
+	start := time.Now()
+	prepared, err := s.preprocessor.Prepare(ctx, filePath)
+	if err != nil {
+		tracing.RecordError(span, err)
+		if errors.Is(err, domainConspect.ErrNoAudioStream) || errors.Is(err, domainConspect.ErrUndecodableAudio) {
+			return nil, apperror.Wrap(apperror.CodeFileUnreadable, "Failed to decode audio", err)
+		}
+		return nil, fmt.Errorf("failed to preprocess audio: %w", err)
+	}
+	s.metrics.ObserveStage(metrics.StagePreprocess, start)
+	if err := validators.ValidateAudioDuration(prepared.Source.Duration); err != nil {
+		if prepared.Path != filePath {
+			os.Remove(prepared.Path)
+		}
+		return nil, err
+	}
+	span.SetAttributes(
+		attribute.String("audio.container", prepared.Source.Container),
+		attribute.String("audio.codec", prepared.Source.Codec),
+		attribute.Float64("audio.speed", prepared.Speed),
+	)
+
+	logging.FromContext(ctx).Infof("Prepared audio: container=%s, codec=%s, duration=%s, speed=%.2f",
+		prepared.Source.Container, prepared.Source.Codec, prepared.Source.Duration, prepared.Speed)
+	return prepared, nil
+}
+
+func (s *TranscriptionService) CanDiarize() bool {
+	return s.diarizer != nil
+}
+
+func (s *TranscriptionService) diarize(ctx context.Context, filePath string, transcript *domainConspect.Transcript) domainConspect.Speakers {
+	ctx, span := tracer.Start(ctx, "pipeline.diarize")
+	defer span.End()
+
+	if s.diarizer == nil {
+		return nil
+	}
+
+	start := time.Now()
+	turns, err := s.diarizer.Diarize(ctx, filePath, transcript.Duration)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logging.FromContext(ctx).Warnf("Diarization failed, continuing without speakers: %v", err)
+		return nil
+	}
+	s.metrics.ObserveStage(metrics.StageDiarize, start)
+
+	lang := i18n.FromContext(ctx)
+	speakers := make(domainConspect.Speakers)
+	for i, label := range domainConspect.AssignSpeakers(transcript.Segments, turns) {
+		speakers[label] = i18n.T(lang, i18n.KeySpeakerName, map[string]any{"n": i + 1})
+	}
+	span.SetAttributes(attribute.Int("diarization.speakers", len(speakers)))
+
+	return speakers
+}
+
+func (s *TranscriptionService) SupportsVideo() bool {
+	return s.slides != nil
+}
+
+func (s *TranscriptionService) extractSlides(ctx context.Context, filePath string) []domainConspect.Slide {
+	ctx, span := tracer.Start(ctx, "pipeline.slides")
+	defer span.End()
+
+	if s.slides == nil {
+		return nil
+	}
+
+	start := time.Now()
+	slides, err := s.slides.ExtractSlides(ctx, filePath)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logging.FromContext(ctx).Warnf("Slide extraction failed, continuing without slides: %v", err)
+		return nil
+	}
+	s.metrics.ObserveStage(metrics.StageSlides, start)
+	span.SetAttributes(attribute.Int("video.slides", len(slides)))
+
+	return slides
+}
+
+func (s *TranscriptionService) SupportsDocument(format domainConspect.DocumentFormat) bool {
+	return s.documents != nil && s.documents.Supports(format)
+}
+
+func (s *TranscriptionService) ExtractDocument(ctx context.Context, filePath string, format domainConspect.DocumentFormat) (*domainConspect.Transcript, error) {
+	ctx, span := tracer.Start(ctx, "pipeline.extract")
+	defer span.End()
+
+	start := time.Now()
+	transcript, err := s.documents.Extract(ctx, filePath, format)
+	if err != nil {
+		tracing.RecordError(span, err)
+		switch {
+		case errors.Is(err, domainConspect.ErrEmptyDocument):
+			return nil, apperror.Wrap(apperror.CodeFileEmpty, "Document contains no text", err)
+		case errors.Is(err, domainConspect.ErrUnreadableDocument):
+			return nil, apperror.Wrap(apperror.CodeFileUnreadable, "Failed to read document", err)
+		}
+		return nil, fmt.Errorf("failed to extract document: %w", err)
 	}
+	s.metrics.ObserveStage(metrics.StageExtract, start)
+	span.SetAttributes(attribute.Int("document.segments", len(transcript.Segments)))
+
+	logging.FromContext(ctx).Infof("Extracted document: format=%s, chars=%d, segments=%d", format, len([]rune(transcript.Text)), len(transcript.Segments))
+	return &transcript, nil
 }
 
-func (s *TranscriptionService) SummarizeAudio(ctx context.Context, filePath, pages, notes string) (string, error) {
+func (s *TranscriptionService) defineTerms(ctx context.Context, glossary *domainGlossary.Glossary, transcript domainConspect.Transcript, target domainConspect.Language) []domainConspect.GlossaryEntry {
+	mentioned := glossary.Mentioned(transcript.Text)
+	if len(mentioned) == 0 {
+		return nil
+	}
+
+	definitions := make(map[string]string, len(mentioned))
+	var undefined []string
+	for _, term := range mentioned {
+		if term.Definition != "" {
+			definitions[strings.ToLower(term.Term)] = term.Definition
+		} else {
+			undefined = append(undefined, term.Term)
+		}
+	}
+
+	if len(undefined) > 0 {
+		output, err := s.complete(ctx, "pipeline.glossary", s.BuildDefinitionMessages(undefined, transcript, target), 0)
+		if err != nil {
+			logging.FromContext(ctx).Warnf("Failed to extract glossary definitions, keeping only user definitions: %v", err)
+		}
+		for term, definition := range parseDefinitions(output, undefined) {
+			definitions[term] = definition
+		}
+	}
+
+	entries := make([]domainConspect.GlossaryEntry, 0, len(definitions))
+	for _, term := range mentioned {
+		if definition, ok := definitions[strings.ToLower(term.Term)]; ok {
+			entries = append(entries, domainConspect.GlossaryEntry{Term: term.Term, Definition: definition})
+		}
+	}
+	return entries
+}
+
+func parseDefinitions(output string, terms []string) map[string]string {
+	requested := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		requested[strings.ToLower(term)] = true
+	}
+
+	definitions := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		term, definition, ok := strings.Cut(line, cornellSeparator)
+		if !ok {
+			continue
+		}
+		term = strings.ToLower(strings.Trim(term, " \t-*•\""))
+		definition = strings.TrimSpace(definition)
+		if requested[term] && definition != "" {
+			definitions[term] = definition
+		}
+	}
+	return definitions
+}
+
+func newTranscript(resp *openai.AudioResponse) domainConspect.Transcript {
+	transcript := domainConspect.Transcript{
+		Text:     resp.Text,
+		Duration: seconds(resp.Duration),
+		Segments: make([]domainConspect.Segment, 0, len(resp.Segments)),
+	}
+	for i, segment := range resp.Segments {
+		transcript.Segments = append(transcript.Segments, domainConspect.Segment{
+			Position: i,
+			Start:    seconds(segment.Start),
+			End:      seconds(segment.End),
+			Text:     strings.TrimSpace(segment.Text),
+		})
+	}
+	return transcript
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
+
+func (s *TranscriptionService) transcribe(ctx context.Context, filePath string, language domainConspect.Language, prompt string) (*openai.AudioResponse, error) {
+	ctx, span := tracer.Start(ctx, "pipeline.transcribe")
+	defer span.End()
+
+	start := time.Now()
 	resp, err := s.client.CreateTranscription(ctx, openai.AudioRequest{
 		Model:    openai.Whisper1,
 		FilePath: filePath,
+		Format:   openai.AudioResponseFormatVerboseJSON,
+		Language: language.Code(),
+		Prompt:   prompt,
 	})
 	if err != nil {
-		return "", err
+		tracing.RecordError(span, err)
+		return nil, err
 	}
-	text := resp.Text
+	s.metrics.ObserveStage(metrics.StageTranscribe, start)
+	s.metrics.AudioSecondsProcessed.Add(resp.Duration)
+	span.SetAttributes(
+		attribute.Float64("audio.duration_seconds", resp.Duration),
+		attribute.String("audio.language", resp.Language),
+	)
 
-	prompt := s.BuildSummaryPrompt(text, pages, notes)
+	return &resp, nil
+}
 
+func (s *TranscriptionService) summarize(ctx context.Context, messages []openai.ChatCompletionMessage, st *style.Style, budget pageBudget) (string, error) {
+	var summary string
+	for attempt := 1; attempt <= maxSummaryAttempts; attempt++ {
+		output, err := s.complete(ctx, "pipeline.summarize", messages, budget.maxTokens)
+		if err != nil {
+			return "", err
+		}
+		summary = output
+
+		violations := st.Check(output, budget.chars)
+		if len(violations) == 0 {
+			return summary, nil
+		}
+
+		for _, violation := range violations {
+			s.metrics.ContractViolations.WithLabelValues(string(violation)).Inc()
+		}
+		logging.FromContext(ctx).
+			WithField("attempt", attempt).
+			WithField("style", st.Name).
+			Warnf("Summary violates the style contract: %v", violations)
+
+		messages = append(messages,
+			openai.ChatCompletionMessage{Role: openai.ChatMessageRoleAssistant, Content: output},
+			retryMessage(violations),
+		)
+	}
+
+	return summary, nil
+}
+
+func (s *TranscriptionService) complete(ctx context.Context, spanName string, messages []openai.ChatCompletionMessage, maxTokens int) (string, error) {
+	ctx, span := tracer.Start(ctx, spanName)
+	defer span.End()
+
+	start := time.Now()
 	summaryResp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
-		Model: openai.GPT4oMini,
-		Messages: []openai.ChatCompletionMessage{
-			{Role: openai.ChatMessageRoleUser, Content: prompt},
-		},
+		Model:     openai.GPT4oMini,
+		Messages:  messages,
+		MaxTokens: maxTokens,
 	})
 	if err != nil {
+		tracing.RecordError(span, err)
 		return "", err
 	}
+	s.metrics.ObserveStage(metrics.StageSummarize, start)
+	span.SetAttributes(
+		attribute.Int("llm.prompt_tokens", summaryResp.Usage.PromptTokens),
+		attribute.Int("llm.completion_tokens", summaryResp.Usage.CompletionTokens),
+	)
+	s.metrics.TokensConsumed.WithLabelValues(openai.GPT4oMini, metrics.TokenTypePrompt).Add(float64(summaryResp.Usage.PromptTokens))
+	s.metrics.TokensConsumed.WithLabelValues(openai.GPT4oMini, metrics.TokenTypeCompletion).Add(float64(summaryResp.Usage.CompletionTokens))
 	if len(summaryResp.Choices) == 0 {
 		return "", nil
 	}
 	return summaryResp.Choices[0].Message.Content, nil
 }
 
-func (s *TranscriptionService) BuildSummaryPrompt(text, pages, notes string) string {
-	prompt := `Создай подробный конспект в виде связного текста, как будто его пишет человек от руки в тетрадь.
-				ВАЖНЫЕ ТРЕБОВАНИЯ:
-				- Пиши ТОЛЬКО связным текстом, БЕЗ ЛЮБОЙ нумерации (ни цифровой, ни маркированной)
-				- НЕ используй списки, маркеры (•, *, -, ⦿), цифры для перечисления
-				- Структурируй мысли абзацами, а не списками
-				- Пиши естественным разговорным языком, как в личных заметках
-				- Избегай формальных фраз типа "в заключение", "таким образом"
-				- Используй простые переходы между мыслями
-				- Текст должен выглядеть как рукописные заметки студента`
+func (s *TranscriptionService) BuildSummaryMessages(transcript domainConspect.Transcript, opts domainConspect.Options, target domainConspect.Language, st *style.Style, glossary *domainGlossary.Glossary, budget pageBudget) ([]openai.ChatCompletionMessage, error) {
+	instructions, err := st.Render(style.PromptData{
+		Language: target.Name(),
+		Pages:    opts.Pages,
+		Params:   opts.StyleParams,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	if pages != "" && pages != "0" {
-		prompt += fmt.Sprintf("Примерный объем: %s страниц рукописного текста.\n", pages)
+	system := strings.TrimSpace(instructions)
+	if budget.chars > 0 {
+		system += fmt.Sprintf("\nTarget length: about %d characters, so that the conspect fills exactly %d pages.", budget.chars, budget.pages)
 	}
 
-	if notes != "" {
-		prompt += fmt.Sprintf("Особенности: %s\n", notes)
+	system += "\n\n" + summarySafetyRules
+	if len(transcript.Segments) > 0 {
+		system += "\n\n" + citationRules
+	}
+	if transcript.HasSpeakers() {
+		system += "\n\n" + speakerRules
 	}
 
-	prompt += "\n---\nТранскрипция:\n" + text
+	var terms string
+	if glossary != nil && len(glossary.Terms) > 0 {
+		system += "\n\n" + glossaryRules
+		for _, term := range glossary.Terms {
+			terms += term.Term + "\n"
+		}
+	}
+
+	return []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: system},
+		{Role: openai.ChatMessageRoleUser, Content: delimit(tagNotes, opts.Notes) + delimit(tagGlossary, terms) + delimit(tagTranscript, transcript.Annotated())},
+	}, nil
+}
 
-	return prompt
+func (s *TranscriptionService) BuildTranslationMessages(text string, from, to domainConspect.Language) []openai.ChatCompletionMessage {
+	return []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: fmt.Sprintf(translationRules, from.Name(), to.Name())},
+		{Role: openai.ChatMessageRoleUser, Content: delimit(tagSource, text)},
+	}
+}
+
+func (s *TranscriptionService) BuildDefinitionMessages(terms []string, transcript domainConspect.Transcript, target domainConspect.Language) []openai.ChatCompletionMessage {
+	return []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: fmt.Sprintf(definitionRules, target.Name())},
+		{Role: openai.ChatMessageRoleUser, Content: delimit(tagGlossary, strings.Join(terms, "\n")) + delimit(tagTranscript, transcript.Text)},
+	}
 }
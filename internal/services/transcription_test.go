@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	domainConspect "github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/metrics"
+)
+
+type fakePreprocessor struct {
+	prepared *domainConspect.PreparedAudio
+}
+
+func (f *fakePreprocessor) Prepare(context.Context, string) (*domainConspect.PreparedAudio, error) {
+	return f.prepared, nil
+}
+
+func TestPrepareRejectsLongAudioInUnknownContainer(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "normalized.mp3")
+	if err := os.WriteFile(output, []byte("audio"), 0o600); err != nil {
+		t.Fatalf("write prepared audio: %v", err)
+	}
+	preprocessor := &fakePreprocessor{prepared: &domainConspect.PreparedAudio{
+		Path:   output,
+		Source: domainConspect.AudioInfo{Container: "avi", Duration: 3 * time.Hour},
+		Speed:  1,
+	}}
+	s := &TranscriptionService{preprocessor: preprocessor, metrics: metrics.New()}
+
+	_, err := s.prepare(context.Background(), "lecture.avi")
+	if err == nil || apperror.From(err).Code != apperror.CodeAudioTooLong {
+		t.Fatalf("prepare() error = %v, want %s", err, apperror.CodeAudioTooLong)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Fatalf("prepared audio was not removed: %v", err)
+	}
+}
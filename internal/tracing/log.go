@@ -0,0 +1,26 @@
+package tracing
+
+import (
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type LogHook struct{}
+
+func (LogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (LogHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	spanCtx := trace.SpanContextFromContext(entry.Context)
+	if spanCtx.IsValid() {
+		entry.Data["trace_id"] = spanCtx.TraceID().String()
+		entry.Data["span_id"] = spanCtx.SpanID().String()
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	"github.com/goIdioms/conspect-generator/internal/domain/conspect"
+)
+
+type ConversionParams struct {
+	Pages          string
+	Notes          string
+	SourceLanguage string
+	TargetLanguage string
+	Bilingual      string
+	Style          string
+	StyleParams    string
+	Diarize        string
+	Glossary       string
+	Slides         string
+}
+
+func ParseConversionOptions(params ConversionParams) (conspect.Options, error) {
+	var opts conspect.Options
+
+	if err := ValidateRequestParams(params.Pages, params.Notes); err != nil {
+		return opts, err
+	}
+
+	var err error
+	if params.Pages != "" {
+		if opts.Pages, err = strconv.Atoi(params.Pages); err != nil {
+			return opts, fmt.Errorf("failed to parse pages: %w", err)
+		}
+	}
+	opts.Notes = params.Notes
+
+	if opts.SourceLanguage, err = ParseLanguage("source_language", params.SourceLanguage); err != nil {
+		return opts, err
+	}
+	if opts.TargetLanguage, err = ParseLanguage("target_language", params.TargetLanguage); err != nil {
+		return opts, err
+	}
+
+	if params.Bilingual != "" {
+		opts.Bilingual, err = strconv.ParseBool(params.Bilingual)
+		if err != nil {
+			return opts, &FileValidationError{
+				Code:    apperror.CodeInvalidParam,
+				Field:   "bilingual",
+				Message: fmt.Sprintf("bilingual is not a boolean: %q", params.Bilingual),
+			}
+		}
+	}
+
+	if params.Diarize != "" {
+		opts.Diarize, err = strconv.ParseBool(params.Diarize)
+		if err != nil {
+			return opts, &FileValidationError{
+				Code:    apperror.CodeInvalidParam,
+				Field:   "diarize",
+				Message: fmt.Sprintf("diarize is not a boolean: %q", params.Diarize),
+			}
+		}
+	}
+
+	if params.Slides != "" {
+		opts.Slides, err = strconv.ParseBool(params.Slides)
+		if err != nil {
+			return opts, &FileValidationError{
+				Code:    apperror.CodeInvalidParam,
+				Field:   "slides",
+				Message: fmt.Sprintf("slides is not a boolean: %q", params.Slides),
+			}
+		}
+	}
+
+	if params.Glossary != "" {
+		opts.GlossaryID, err = strconv.Atoi(params.Glossary)
+		if err != nil || opts.GlossaryID <= 0 {
+			return opts, &FileValidationError{
+				Code:    apperror.CodeInvalidParam,
+				Field:   "glossary",
+				Message: fmt.Sprintf("glossary is not a valid ID: %q", params.Glossary),
+			}
+		}
+	}
+
+	opts.Style = params.Style
+	if params.StyleParams != "" {
+		if err := json.Unmarshal([]byte(params.StyleParams), &opts.StyleParams); err != nil {
+			return opts, &FileValidationError{
+				Code:    apperror.CodeInvalidParam,
+				Field:   "style_params",
+				Message: "style_params must be a JSON object with string values",
+				Params:  map[string]any{"reason": "expected a JSON object with string values"},
+			}
+		}
+	}
+
+	return opts, nil
+}
+
+func ParseLanguage(field, value string) (conspect.Language, error) {
+	if value == "" {
+		return conspect.Language{}, nil
+	}
+
+	language, err := conspect.NewLanguage(value)
+	if err != nil {
+		return conspect.Language{}, &FileValidationError{
+			Code:    apperror.CodeUnsupportedLanguage,
+			Field:   field,
+			Message: fmt.Sprintf("unsupported language: %q", value),
+			Params:  map[string]any{"language": value},
+		}
+	}
+	return language, nil
+}
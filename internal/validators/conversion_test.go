@@ -0,0 +1,38 @@
+package validators
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseConversionOptionsPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		pages   string
+		want    int
+		wantErr bool
+	}{
+		{"empty", "", 0, false},
+		{"within range", "3", 3, false},
+		{"trailing garbage", "3abc", 0, true},
+		{"surrounding spaces", " 3", 0, true},
+		{"below range", "0", 0, true},
+		{"above range", "51", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseConversionOptions(ConversionParams{Pages: tt.pages})
+			if tt.wantErr {
+				var validationErr *FileValidationError
+				if !errors.As(err, &validationErr) || validationErr.Field != "pages" {
+					t.Fatalf("ParseConversionOptions() error = %v, want a pages validation error", err)
+				}
+				return
+			}
+			if err != nil || opts.Pages != tt.want {
+				t.Fatalf("ParseConversionOptions() = %d, %v, want %d", opts.Pages, err, tt.want)
+			}
+		})
+	}
+}
@@ -1,16 +1,32 @@
 package validators
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
-	"net/http"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goIdioms/conspect-generator/internal/apperror"
+	"github.com/goIdioms/conspect-generator/internal/domain/conspect"
+	"github.com/goIdioms/conspect-generator/internal/infra/media"
 )
 
 const (
 	MaxFileSize      = 100 * 1024 * 1024
-	MaxAudioDuration = 2 * 60 * 60
+	MaxVideoFileSize = 1024 * 1024 * 1024
+	MaxAudioDuration = 2 * time.Hour
+
+	MaxDocumentFileSize = 20 * 1024 * 1024
+	MaxDocumentChars    = 300000
+
+	MinPages       = 1
+	MaxPages       = 50
+	MaxNotesLength = 1000
 )
 
 var AllowedMimeTypes = map[string]bool{
@@ -24,107 +40,257 @@ var AllowedMimeTypes = map[string]bool{
 	"audio/mp4":                true,
 	"audio/webm":               true,
 	"video/webm":               true,
+	"video/mp4":                true,
+	"video/x-m4v":              true,
+	"video/x-matroska":         true,
+	"video/quicktime":          true,
+	"video/x-msvideo":          true,
+	"application/octet-stream": true,
+}
+
+var AllowedExtensions = []string{".mp3", ".wav", ".ogg", ".m4a", ".mp4", ".webm", ".oga", ".m4v", ".mkv", ".mov", ".avi"}
+
+var VideoExtensions = []string{".mp4", ".webm", ".m4v", ".mkv", ".mov", ".avi"}
+
+var AllowedDocumentMimeTypes = map[string]bool{
+	"text/plain":               true,
+	"text/markdown":            true,
+	"text/x-markdown":          true,
+	"application/x-subrip":     true,
+	"text/vtt":                 true,
+	"application/pdf":          true,
+	"application/zip":          true,
 	"application/octet-stream": true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": true,
 }
 
+var DocumentExtensions = []string{".txt", ".md", ".markdown", ".srt", ".vtt", ".docx", ".pdf"}
+
+var videoOnlyExtensions = []string{".m4v", ".mkv", ".mov", ".avi"}
+
 type FileValidationError struct {
+	Code    apperror.Code
 	Field   string
 	Message string
+	Params  map[string]any
 }
 
 func (e *FileValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
-func ValidateAudioFile(file multipart.File, header *multipart.FileHeader) error {
-	if header.Size > MaxFileSize {
+func (e *FileValidationError) AppError() *apperror.Error {
+	appErr := apperror.New(e.Code, e.Message).WithField(e.Field)
+	for key, value := range e.Params {
+		appErr.WithDetail(key, value)
+	}
+	return appErr
+}
+
+func MaxAudioFileSize(filename string) int64 {
+	if hasExtension(filename, VideoExtensions) {
+		return MaxVideoFileSize
+	}
+	return MaxFileSize
+}
+
+func ValidateAudioFile(header *multipart.FileHeader, contentType string) error {
+	if maxSize := MaxAudioFileSize(header.Filename); header.Size > maxSize {
+		return fileTooLarge(header.Size, maxSize)
+	}
+
+	if header.Size == 0 {
 		return &FileValidationError{
+			Code:    apperror.CodeFileEmpty,
 			Field:   "file",
-			Message: fmt.Sprintf("Файл слишком большой. Максимум: %dMB", MaxFileSize/(1024*1024)),
+			Message: "file is empty",
 		}
 	}
 
-	if header.Size == 0 {
+	declaredType := header.Header.Get("Content-Type")
+	if !AllowedMimeTypes[declaredType] {
 		return &FileValidationError{
+			Code:    apperror.CodeUnsupportedMime,
 			Field:   "file",
-			Message: "Файл пустой",
+			Message: fmt.Sprintf("unsupported content type: %s", declaredType),
+			Params:  map[string]any{"content_type": declaredType},
 		}
 	}
 
-	if !AllowedMimeTypes[header.Header.Get("Content-Type")] {
+	if !strings.HasPrefix(contentType, "audio/") &&
+		!strings.HasPrefix(contentType, "video/") &&
+		contentType != "application/octet-stream" {
 		return &FileValidationError{
+			Code:    apperror.CodeUnsupportedMime,
 			Field:   "file",
-			Message: fmt.Sprintf("Неподдерживаемый тип файла: %s. Разрешены только аудио файлы", header.Header.Get("Content-Type")),
+			Message: fmt.Sprintf("detected content type is not audio: %s", contentType),
+			Params:  map[string]any{"content_type": contentType},
 		}
 	}
 
-	buffer := make([]byte, 512)
-	n, err := file.Read(buffer)
-	if err != nil && err != io.EOF {
+	if !hasExtension(header.Filename, AllowedExtensions) {
 		return &FileValidationError{
+			Code:    apperror.CodeUnsupportedExtension,
 			Field:   "file",
-			Message: "Не удалось прочитать файл",
+			Message: fmt.Sprintf("unsupported file extension: %s", header.Filename),
+			Params:  map[string]any{"extensions": strings.Join(AllowedExtensions, ", ")},
 		}
 	}
 
-	if _, err := file.Seek(0, 0); err != nil {
-		return &FileValidationError{
+	return nil
+}
+
+func ValidateAudioMetadata(file io.ReaderAt, size int64) (conspect.AudioInfo, error) {
+	info, err := media.Probe(file, size)
+	if errors.Is(err, media.ErrUnknownFormat) {
+		return info, nil
+	}
+	if err != nil {
+		return info, &FileValidationError{
+			Code:    apperror.CodeFileUnreadable,
 			Field:   "file",
-			Message: "Ошибка обработки файла",
+			Message: fmt.Sprintf("failed to read audio metadata: %v", err),
 		}
 	}
 
-	contentType := http.DetectContentType(buffer[:n])
-	if !strings.HasPrefix(contentType, "audio/") &&
-		!strings.HasPrefix(contentType, "video/") &&
-		contentType != "application/octet-stream" {
+	return info, ValidateAudioDuration(info.Duration)
+}
+
+func ValidateAudioDuration(duration time.Duration) error {
+	if duration > MaxAudioDuration {
 		return &FileValidationError{
+			Code:    apperror.CodeAudioTooLong,
 			Field:   "file",
-			Message: fmt.Sprintf("Файл не является аудио. Обнаружен тип: %s", contentType),
+			Message: fmt.Sprintf("audio is too long: %s", duration.Round(time.Second)),
+			Params: map[string]any{
+				"duration_min": int(duration.Minutes()),
+				"max_min":      int(MaxAudioDuration.Minutes()),
+			},
 		}
 	}
+	return nil
+}
 
-	filename := strings.ToLower(header.Filename)
-	allowedExtensions := []string{".mp3", ".wav", ".ogg", ".m4a", ".mp4", ".webm", ".oga"}
-	hasValidExtension := false
-	for _, ext := range allowedExtensions {
-		if strings.HasSuffix(filename, ext) {
-			hasValidExtension = true
-			break
+func ValidateVideo(header *multipart.FileHeader, info conspect.AudioInfo, supported bool) (bool, error) {
+	video := info.Video || hasExtension(header.Filename, videoOnlyExtensions)
+	if !video && header.Size > MaxFileSize {
+		return false, fileTooLarge(header.Size, MaxFileSize)
+	}
+
+	if video && !supported {
+		return true, &FileValidationError{
+			Code:    apperror.CodeVideoUnsupported,
+			Field:   "file",
+			Message: "video uploads require ffmpeg",
 		}
 	}
 
-	if !hasValidExtension {
-		return &FileValidationError{
+	return video, nil
+}
+
+func ValidateDocumentFile(header *multipart.FileHeader, contentType string) (conspect.DocumentFormat, error) {
+	if header.Size > MaxDocumentFileSize {
+		return "", fileTooLarge(header.Size, MaxDocumentFileSize)
+	}
+
+	if header.Size == 0 {
+		return "", &FileValidationError{
+			Code:    apperror.CodeFileEmpty,
 			Field:   "file",
-			Message: fmt.Sprintf("Неподдерживаемое расширение файла. Разрешены: %v", allowedExtensions),
+			Message: "file is empty",
 		}
 	}
 
+	format, ok := conspect.DocumentFormatFromFilename(header.Filename)
+	if !ok {
+		return "", unsupportedDocument(fmt.Sprintf("unsupported document extension: %s", header.Filename))
+	}
+
+	declaredType, _, _ := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	if declaredType == "" {
+		declaredType = "application/octet-stream"
+	}
+	if !AllowedDocumentMimeTypes[declaredType] {
+		return "", unsupportedDocument(fmt.Sprintf("unsupported content type: %s", declaredType))
+	}
+
+	detectedType, _, _ := mime.ParseMediaType(contentType)
+	var matches bool
+	switch {
+	case format.IsText():
+		matches = strings.HasPrefix(detectedType, "text/")
+	case format == conspect.DocumentDOCX:
+		matches = detectedType == "application/zip"
+	case format == conspect.DocumentPDF:
+		matches = detectedType == "application/pdf"
+	}
+	if !matches {
+		return "", unsupportedDocument(fmt.Sprintf("detected content type %s does not match %s", detectedType, format))
+	}
+
+	return format, nil
+}
+
+func ValidateDocumentText(transcript conspect.Transcript) error {
+	chars := utf8.RuneCountInString(transcript.Text)
+	if chars > MaxDocumentChars {
+		return &FileValidationError{
+			Code:    apperror.CodeDocumentTooLong,
+			Field:   "file",
+			Message: fmt.Sprintf("document is too long: %d characters", chars),
+			Params:  map[string]any{"chars": chars, "max_chars": MaxDocumentChars},
+		}
+	}
 	return nil
 }
 
+func unsupportedDocument(message string) *FileValidationError {
+	return &FileValidationError{
+		Code:    apperror.CodeUnsupportedDocument,
+		Field:   "file",
+		Message: message,
+		Params:  map[string]any{"extensions": strings.Join(DocumentExtensions, ", ")},
+	}
+}
+
+func fileTooLarge(size, maxSize int64) *FileValidationError {
+	return &FileValidationError{
+		Code:    apperror.CodeFileTooLarge,
+		Field:   "file",
+		Message: fmt.Sprintf("file is too large: %d bytes", size),
+		Params:  map[string]any{"max_mb": maxSize / (1024 * 1024)},
+	}
+}
+
+func hasExtension(filename string, extensions []string) bool {
+	filename = strings.ToLower(filename)
+	for _, ext := range extensions {
+		if strings.HasSuffix(filename, ext) {
+			return true
+		}
+	}
+	return false
+}
+
 func ValidateRequestParams(pages, notes string) error {
 	if pages != "" {
-		var pagesNum int
-		if _, err := fmt.Sscanf(pages, "%d", &pagesNum); err != nil {
-			return &FileValidationError{
-				Field:   "pages",
-				Message: "pages должен быть числом",
-			}
-		}
-		if pagesNum < 1 || pagesNum > 50 {
+		pagesNum, err := strconv.Atoi(pages)
+		if err != nil || pagesNum < MinPages || pagesNum > MaxPages {
 			return &FileValidationError{
+				Code:    apperror.CodeInvalidParam,
 				Field:   "pages",
-				Message: "pages должен быть от 1 до 50",
+				Message: fmt.Sprintf("pages is out of range: %q", pages),
+				Params:  map[string]any{"min": MinPages, "max": MaxPages},
 			}
 		}
 	}
 
-	if len(notes) > 1000 {
+	if len(notes) > MaxNotesLength {
 		return &FileValidationError{
+			Code:    apperror.CodeInvalidParam,
 			Field:   "notes",
-			Message: "notes слишком длинный (максимум 1000 символов)",
+			Message: fmt.Sprintf("notes is too long: %d characters", len(notes)),
+			Params:  map[string]any{"max_length": MaxNotesLength},
 		}
 	}
 
@@ -8,17 +8,18 @@ export async function POST(request: NextRequest) {
     const file: File | null = data.get('audio') as unknown as File;
     const pages = data.get('pages') as string || '1';
     const notes = data.get('notes') as string || '';
+    const uploadId = data.get('upload_id') as string || '';
 
-    if (!file) {
+    if (!file && !uploadId) {
       return NextResponse.json({ error: 'Файл не найден' }, { status: 400 });
     }
 
-    if (!file.type.startsWith('audio/')) {
+    if (file && !file.type.startsWith('audio/') && !file.type.startsWith('video/')) {
       return NextResponse.json({ error: 'Неверный тип файла' }, { status: 400 });
     }
 
     try {
-      const healthCheck = await fetch(`${BACKEND_URL}/`, {
+      const healthCheck = await fetch(`${BACKEND_URL}/healthz`, {
         method: 'GET'
       });
       if (!healthCheck.ok) {
@@ -32,19 +33,38 @@ export async function POST(request: NextRequest) {
     }
 
     const backendFormData = new FormData();
-    backendFormData.append('file', file);
+    if (uploadId) {
+      backendFormData.append('upload_id', uploadId);
+    } else {
+      backendFormData.append('file', file);
+    }
     backendFormData.append('pages', pages);
     backendFormData.append('notes', notes);
+    for (const field of ['source_language', 'target_language', 'bilingual', 'style', 'style_params', 'diarize', 'glossary', 'slides']) {
+      const value = data.get(field);
+      if (typeof value === 'string' && value !== '') {
+        backendFormData.append(field, value);
+      }
+    }
 
     const backendResponse = await fetch(`${BACKEND_URL}/audio`, {
       method: 'POST',
-      body: backendFormData
+      body: backendFormData,
+      headers: {
+        cookie: request.headers.get('cookie') ?? '',
+        'accept-language': request.headers.get('accept-language') ?? '',
+      },
     });
 
     if (!backendResponse.ok) {
-      const errorText = await backendResponse.text();
+      const problem = await backendResponse.json().catch(() => null);
       return NextResponse.json(
-        { error: `Backend error: ${backendResponse.status} - ${errorText}` },
+        {
+          error: problem?.detail ?? `Backend error: ${backendResponse.status}`,
+          code: problem?.code,
+          field: problem?.field,
+          requestId: problem?.request_id,
+        },
         { status: backendResponse.status }
       );
     }
@@ -43,7 +43,7 @@ export default function FileUploadZone({
       <input
         ref={fileInputRef}
         type="file"
-        accept="audio/*"
+        accept="audio/*,video/*"
         onChange={onFileSelect}
         className="hidden"
       />